@@ -0,0 +1,30 @@
+// Package aoctest holds helpers shared by the tests of the days. It's only
+// imported by tests, keeping package testing out of the binaries.
+package aoctest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"aoc24/aoc"
+)
+
+// FuzzSeeds adds the examples of a day, the example*.txt files in the
+// current directory, along with an input made by its generator to the seed
+// corpus of a fuzz test.
+func FuzzSeeds(f *testing.F, generate aoc.Generator) {
+	f.Helper()
+
+	examples, _ := filepath.Glob("example*.txt")
+	for _, path := range examples {
+		example, err := os.ReadFile(path)
+		if err != nil {
+			f.Fatalf("unable to read example: %v", err)
+		}
+
+		f.Add(example)
+	}
+
+	f.Add(generate(aoc.NewRand(1), 10))
+}
@@ -0,0 +1,55 @@
+package aoc
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+)
+
+// Command is an extra command offered by a day, next to solving its parts,
+// such as a different view on the puzzle input.
+type Command struct {
+	Name string
+
+	// Summary describes what the command does in a single line.
+	Summary string
+
+	// Run runs the command against the puzzle input, writing its output to
+	// out. The arguments following the name of the command are passed in
+	// args, which the command parses on its own.
+	Run func(input io.Reader, out io.Writer, args []string) error
+}
+
+var commands = map[int]map[string]Command{}
+
+// RegisterCommand adds a command to the given day. Like Register, it is meant
+// to be called from the init function of each day's package and panics when
+// a day registers two commands by the same name.
+func RegisterCommand(day int, c Command) {
+	if _, exists := commands[day][c.Name]; exists {
+		panic(fmt.Errorf("command %q for day %d already registered", c.Name, day))
+	}
+
+	if commands[day] == nil {
+		commands[day] = map[string]Command{}
+	}
+
+	commands[day][c.Name] = c
+}
+
+// LookupCommand returns the command of the given day by name.
+func LookupCommand(day int, name string) (Command, bool) {
+	c, found := commands[day][name]
+	return c, found
+}
+
+// Commands returns the commands of the given day ordered by name.
+func Commands(day int) []Command {
+	var res []Command
+	for _, name := range slices.Sorted(maps.Keys(commands[day])) {
+		res = append(res, commands[day][name])
+	}
+
+	return res
+}
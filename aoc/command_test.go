@@ -0,0 +1,35 @@
+package aoc
+
+import (
+	"io"
+	"testing"
+)
+
+func TestRegisterCommand(t *testing.T) {
+	run := func(io.Reader, io.Writer, []string) error { return nil }
+
+	RegisterCommand(100, Command{Name: "view", Run: run})
+	RegisterCommand(100, Command{Name: "count", Run: run})
+	defer delete(commands, 100)
+
+	c, found := LookupCommand(100, "view")
+	assert(t, found, true, "command should be found")
+	assert(t, c.Name, "view", "incorrect command")
+
+	_, found = LookupCommand(100, "missing")
+	assert(t, found, false, "unknown command shouldn't be found")
+
+	_, found = LookupCommand(101, "view")
+	assert(t, found, false, "command of another day shouldn't be found")
+
+	var names []string
+	for _, c := range Commands(100) {
+		names = append(names, c.Name)
+	}
+	assert(t, names, []string{"count", "view"}, "commands should be ordered by name")
+
+	defer func() {
+		assert(t, recover() != nil, true, "registering a command twice should panic")
+	}()
+	RegisterCommand(100, Command{Name: "view", Run: run})
+}
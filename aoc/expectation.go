@@ -0,0 +1,104 @@
+package aoc
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"aoc24/parse"
+)
+
+// Expectation records the known answers of a day for a single input file.
+// Parts without a known answer are left out of Answers.
+type Expectation struct {
+	Day     int
+	Input   string
+	Answers map[Part]int
+}
+
+// ReadExpectations reads the expectations from the answers file at path.
+func ReadExpectations(path string) ([]Expectation, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseExpectations(f)
+}
+
+// ParseExpectations parses an answers file. Each line holds a day, the path
+// of an input file and the answers to part one and two, separated by
+// whitespace. Unknown answers are written as "-", everything following a "#"
+// is a comment:
+//
+//	# day  input              part one  part two
+//	7      day_07/example.txt 3749      11387
+//	16     day_16/example.txt 7036      -
+func ParseExpectations(input io.Reader) ([]Expectation, error) {
+	var (
+		scanner      = bufio.NewScanner(input)
+		expectations []Expectation
+		lineNo       int
+	)
+
+	for scanner.Scan() {
+		lineNo++
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := parse.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if len(fields) != 4 {
+			return nil, parse.Errorf(lineNo, 1, line, "expected day, input, part one and part two, found %d fields", len(fields))
+		}
+
+		day, err := fields[0].Int(lineNo)
+		if err != nil {
+			return nil, err
+		}
+
+		e := Expectation{
+			Day:     day,
+			Input:   fields[1].Text,
+			Answers: map[Part]int{},
+		}
+
+		for idx, part := range Parts {
+			field := fields[2+idx]
+			if field.Text == "-" {
+				continue
+			}
+
+			answer, err := field.Int(lineNo)
+			if err != nil {
+				return nil, err
+			}
+
+			e.Answers[part] = answer
+		}
+
+		expectations = append(expectations, e)
+	}
+
+	return expectations, scanner.Err()
+}
+
+// String formats the expectation the way it's written in an answers file.
+func (e Expectation) String() string {
+	fields := []string{strconv.Itoa(e.Day), e.Input}
+	for _, part := range Parts {
+		answer, known := e.Answers[part]
+		if !known {
+			fields = append(fields, "-")
+			continue
+		}
+
+		fields = append(fields, strconv.Itoa(answer))
+	}
+
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,42 @@
+package aoc
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"aoc24/parse"
+)
+
+func assert(t *testing.T, a, b any, msg string) {
+	t.Helper()
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("assertion failed: %s. Expected %+v, got %+v", msg, b, a)
+	}
+}
+
+func TestParseExpectations(t *testing.T) {
+	input := "# day input one two\n\n7 day_07/example.txt 3749 11387\n16 day_16/example.txt 7036 - # no part two\n"
+	expectations, err := ParseExpectations(strings.NewReader(input))
+	assert(t, err, nil, "unexpected error")
+	assert(t, expectations, []Expectation{
+		{Day: 7, Input: "day_07/example.txt", Answers: map[Part]int{PartOne: 3749, PartTwo: 11387}},
+		{Day: 16, Input: "day_16/example.txt", Answers: map[Part]int{PartOne: 7036}},
+	}, "incorrect expectations")
+	assert(t, expectations[1].String(), "16 day_16/example.txt 7036 -", "incorrect string")
+
+	t.Run("invalid answer", func(t *testing.T) {
+		_, err := ParseExpectations(strings.NewReader("1 day_01/example.txt 11 x\n"))
+		var perr *parse.Error
+		assert(t, errors.As(err, &perr), true, "expected parse error")
+		assert(t, perr.Line, 1, "incorrect line")
+		assert(t, perr.Column, 25, "incorrect column")
+	})
+
+	t.Run("missing answer", func(t *testing.T) {
+		_, err := ParseExpectations(strings.NewReader("1 day_01/example.txt 11\n"))
+		var perr *parse.Error
+		assert(t, errors.As(err, &perr), true, "expected parse error")
+	})
+}
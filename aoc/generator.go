@@ -0,0 +1,58 @@
+package aoc
+
+import (
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+)
+
+// Generator produces a random but valid puzzle input for a day. What size
+// means depends on the day, such as the number of reports or the width and
+// height of a map, as documented by each day's generator. The same random
+// source and size always produce the same input.
+type Generator func(rng *rand.Rand, size int) []byte
+
+var generators = map[int]Generator{}
+
+// RegisterGenerator makes the input generator available for the given day.
+// Like Register, it is meant to be called from the init function of each
+// day's package and panics when a day is registered twice.
+func RegisterGenerator(day int, g Generator) {
+	if _, exists := generators[day]; exists {
+		panic(fmt.Errorf("generator for day %d already registered", day))
+	}
+
+	generators[day] = g
+}
+
+// LookupGenerator returns the input generator registered for day.
+func LookupGenerator(day int) (Generator, bool) {
+	g, found := generators[day]
+	return g, found
+}
+
+// GeneratorDays returns all days with a registered generator in ascending
+// order.
+func GeneratorDays() []int {
+	return slices.Sorted(maps.Keys(generators))
+}
+
+// Generate produces an input of the given size for a day, seeded by seed.
+func Generate(day int, seed uint64, size int) ([]byte, error) {
+	g, found := LookupGenerator(day)
+	if !found {
+		return nil, fmt.Errorf("no generator registered for day %d", day)
+	}
+
+	if size < 1 {
+		return nil, fmt.Errorf("invalid size %d", size)
+	}
+
+	return g(NewRand(seed), size), nil
+}
+
+// NewRand returns a random source seeded by seed.
+func NewRand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed))
+}
@@ -0,0 +1,71 @@
+package aoc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Result records the outcome of solving a single part of a day.
+type Result struct {
+	Day    int  `json:"day"`
+	Part   Part `json:"part"`
+	Answer int  `json:"answer"`
+
+	// Duration is the time it took to parse the input and solve the part,
+	// encoded in JSON as a number of nanoseconds.
+	Duration time.Duration `json:"duration"`
+
+	// InputHash is the hex encoded SHA-256 hash of the input, which tells
+	// apart results for different inputs of the same day.
+	InputHash string `json:"input_hash"`
+}
+
+// Run solves the given part of a day against input and records the result.
+func Run(day int, part Part, input []byte) (Result, error) {
+	return RunReader(day, part, bytes.NewReader(input))
+}
+
+// RunReader is like Run, but streams the input from r and hashes it along
+// the way, so it never needs to be held in memory as a whole.
+func RunReader(day int, part Part, r io.Reader) (Result, error) {
+	solver, found := Lookup(day)
+	if !found {
+		return Result{}, fmt.Errorf("no solver registered for day %d", day)
+	}
+
+	var (
+		h     = sha256.New()
+		start = time.Now()
+	)
+
+	answer, err := Solve(solver, part, io.TeeReader(r, h))
+	if err != nil {
+		return Result{}, err
+	}
+
+	duration := time.Since(start)
+
+	// the hash covers the entire input, even when the solver stopped
+	// reading early
+	if _, err := io.Copy(h, r); err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		Day:       day,
+		Part:      part,
+		Answer:    answer,
+		Duration:  duration,
+		InputHash: hex.EncodeToString(h.Sum(nil)),
+	}, nil
+}
+
+// HashInput returns the hex encoded SHA-256 hash of input.
+func HashInput(input []byte) string {
+	sum := sha256.Sum256(input)
+	return hex.EncodeToString(sum[:])
+}
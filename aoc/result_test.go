@@ -0,0 +1,70 @@
+package aoc
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+type lengthSolver struct{}
+
+func (lengthSolver) PartOne(input io.Reader) (int, error) {
+	b, err := io.ReadAll(input)
+	return len(b), err
+}
+
+func (lengthSolver) PartTwo(io.Reader) (int, error) {
+	return 0, ErrNotImplemented
+}
+
+// peekSolver only reads the first byte of the input.
+type peekSolver struct{}
+
+func (peekSolver) PartOne(input io.Reader) (int, error) {
+	b := make([]byte, 1)
+	_, err := input.Read(b)
+	return int(b[0]), err
+}
+
+func (peekSolver) PartTwo(io.Reader) (int, error) {
+	return 0, ErrNotImplemented
+}
+
+func TestRun(t *testing.T) {
+	Register(100, lengthSolver{})
+	defer delete(registry, 100)
+
+	r, err := Run(100, PartOne, []byte("abc"))
+	assert(t, err, nil, "unexpected error")
+	assert(t, r.Day, 100, "incorrect day")
+	assert(t, r.Part, PartOne, "incorrect part")
+	assert(t, r.Answer, 3, "incorrect answer")
+	assert(t, r.InputHash, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", "incorrect input hash")
+
+	r.Duration = 1500
+	b, err := json.Marshal(r)
+	assert(t, err, nil, "unexpected error")
+	assert(t, string(b), `{"day":100,"part":1,"answer":3,"duration":1500,"input_hash":"ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"}`, "incorrect json")
+
+	_, err = Run(100, PartTwo, []byte("abc"))
+	assert(t, errors.Is(err, ErrNotImplemented), true, "expected not implemented")
+
+	_, err = Run(101, PartOne, nil)
+	assert(t, err != nil, true, "expected error for unregistered day")
+}
+
+func TestRunReader(t *testing.T) {
+	Register(100, peekSolver{})
+	defer delete(registry, 100)
+
+	r, err := RunReader(100, PartOne, iotest.OneByteReader(strings.NewReader("abc")))
+	assert(t, err, nil, "unexpected error")
+	assert(t, r.Answer, int('a'), "incorrect answer")
+	assert(t, r.InputHash, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", "hash should cover the input the solver didn't read")
+
+	_, err = RunReader(100, PartOne, iotest.ErrReader(errors.New("boom")))
+	assert(t, err != nil, true, "expected read error")
+}
@@ -0,0 +1,81 @@
+package aoc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+)
+
+// ErrNotImplemented is returned by solvers for parts that haven't been
+// solved yet.
+var ErrNotImplemented = errors.New("not implemented")
+
+type Part int
+
+const (
+	PartOne Part = iota + 1
+	PartTwo
+)
+
+var Parts = []Part{
+	PartOne,
+	PartTwo,
+}
+
+func (p Part) String() string {
+	switch p {
+	case PartOne:
+		return "part one"
+
+	case PartTwo:
+		return "part two"
+	}
+
+	return fmt.Sprintf("part %d", int(p))
+}
+
+// Solver solves both parts of a single day. Each part receives the raw
+// puzzle input and parses it on its own, so the parts can run independently.
+type Solver interface {
+	PartOne(input io.Reader) (int, error)
+	PartTwo(input io.Reader) (int, error)
+}
+
+// Solve runs the given part of the solver against input.
+func Solve(s Solver, part Part, input io.Reader) (int, error) {
+	switch part {
+	case PartOne:
+		return s.PartOne(input)
+
+	case PartTwo:
+		return s.PartTwo(input)
+	}
+
+	return 0, fmt.Errorf("invalid part: %d", int(part))
+}
+
+var registry = map[int]Solver{}
+
+// Register makes the solver available for the given day. It is meant to be
+// called from the init function of each day's package and panics when a
+// day is registered twice.
+func Register(day int, s Solver) {
+	if _, exists := registry[day]; exists {
+		panic(fmt.Errorf("solver for day %d already registered", day))
+	}
+
+	registry[day] = s
+}
+
+// Lookup returns the solver registered for day.
+func Lookup(day int) (Solver, bool) {
+	s, found := registry[day]
+	return s, found
+}
+
+// Days returns all registered days in ascending order.
+func Days() []int {
+	return slices.Sorted(maps.Keys(registry))
+}
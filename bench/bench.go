@@ -0,0 +1,59 @@
+// Package bench benchmarks the registered solvers and keeps a history of the
+// results, so a run can be compared against the one before it.
+package bench
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"aoc24/aoc"
+)
+
+// Result records the benchmark of a single part of a day against an input.
+type Result struct {
+	Day       int      `json:"day"`
+	Part      aoc.Part `json:"part"`
+	InputHash string   `json:"input_hash"`
+
+	// Iterations is the number of times the part was solved.
+	Iterations  int   `json:"iterations"`
+	NsPerOp     int64 `json:"ns_per_op"`
+	AllocsPerOp int64 `json:"allocs_per_op"`
+	BytesPerOp  int64 `json:"bytes_per_op"`
+
+	// Time is the moment the benchmark was taken.
+	Time time.Time `json:"time"`
+}
+
+// Run benchmarks the given part of a day against input. The part is solved
+// once up front, returning its error rather than benchmarking a failure.
+func Run(day int, part aoc.Part, input []byte) (Result, error) {
+	solver, found := aoc.Lookup(day)
+	if !found {
+		return Result{}, fmt.Errorf("no solver registered for day %d", day)
+	}
+
+	if _, err := aoc.Solve(solver, part, bytes.NewReader(input)); err != nil {
+		return Result{}, err
+	}
+
+	br := testing.Benchmark(func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			aoc.Solve(solver, part, bytes.NewReader(input))
+		}
+	})
+
+	return Result{
+		Day:         day,
+		Part:        part,
+		InputHash:   aoc.HashInput(input),
+		Iterations:  br.N,
+		NsPerOp:     br.NsPerOp(),
+		AllocsPerOp: br.AllocsPerOp(),
+		BytesPerOp:  br.AllocedBytesPerOp(),
+		Time:        time.Now(),
+	}, nil
+}
@@ -0,0 +1,70 @@
+package bench
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"aoc24/aoc"
+)
+
+func assert(t *testing.T, a, b any, msg string) {
+	t.Helper()
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("assertion failed: %s. Expected %+v, got %+v", msg, b, a)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bench.ndjson")
+
+	results, err := ReadHistory(path)
+	assert(t, err, nil, "missing history should not fail")
+	assert(t, len(results), 0, "missing history should be empty")
+
+	first := Result{Day: 1, Part: aoc.PartOne, InputHash: "abc", NsPerOp: 100, Time: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)}
+	second := Result{Day: 1, Part: aoc.PartTwo, InputHash: "abc", NsPerOp: 200, Time: time.Date(2024, 12, 2, 0, 0, 0, 0, time.UTC)}
+	assert(t, AppendHistory(path, []Result{first}), nil, "unexpected error")
+	assert(t, AppendHistory(path, []Result{second}), nil, "unexpected error")
+
+	results, err = ReadHistory(path)
+	assert(t, err, nil, "unexpected error")
+	assert(t, results, []Result{first, second}, "incorrect history")
+}
+
+func TestCompare(t *testing.T) {
+	history := []Result{
+		{Day: 1, Part: aoc.PartOne, InputHash: "abc", NsPerOp: 100},
+		{Day: 1, Part: aoc.PartOne, InputHash: "abc", NsPerOp: 200},
+		{Day: 1, Part: aoc.PartTwo, InputHash: "abc", NsPerOp: 100},
+	}
+
+	current := []Result{
+		{Day: 1, Part: aoc.PartOne, InputHash: "abc", NsPerOp: 210},
+		{Day: 1, Part: aoc.PartTwo, InputHash: "abc", NsPerOp: 150},
+		{Day: 1, Part: aoc.PartTwo, InputHash: "def", NsPerOp: 150},
+	}
+
+	comparisons := Compare(history, current)
+	assert(t, len(comparisons), 3, "incorrect number of comparisons")
+
+	// compares against the latest run, 200ns
+	assert(t, comparisons[0].Previous.NsPerOp, int64(200), "should compare against latest run")
+	assert(t, comparisons[0].Delta(), 0.05, "incorrect delta")
+	assert(t, comparisons[0].Regressed(0.1), false, "5% slowdown is within threshold")
+
+	assert(t, comparisons[1].Delta(), 0.5, "incorrect delta")
+	assert(t, comparisons[1].Regressed(0.1), true, "50% slowdown should regress")
+
+	// other inputs aren't comparable
+	assert(t, comparisons[2].Previous == nil, true, "other input should have no previous run")
+	assert(t, comparisons[2].Regressed(0.1), false, "new input can't regress")
+
+	var b strings.Builder
+	regressions, err := Report(&b, comparisons, 0.1)
+	assert(t, err, nil, "unexpected error")
+	assert(t, regressions, 1, "incorrect number of regressions")
+	assert(t, strings.Count(b.String(), "REGRESSION"), 1, "expected a single flagged regression")
+}
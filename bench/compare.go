@@ -0,0 +1,96 @@
+package bench
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"aoc24/aoc"
+)
+
+// Comparison holds the benchmark of a part next to the most recent previous
+// benchmark of that same part against the same input, if there is one.
+type Comparison struct {
+	Current  Result
+	Previous *Result
+}
+
+// Delta returns the relative change in time per operation, where 0.1 means
+// the current run is 10% slower than the previous one.
+func (c Comparison) Delta() float64 {
+	if c.Previous == nil || c.Previous.NsPerOp == 0 {
+		return 0
+	}
+
+	return float64(c.Current.NsPerOp-c.Previous.NsPerOp) / float64(c.Previous.NsPerOp)
+}
+
+// Regressed reports whether the current run is slower than the previous one
+// by more than threshold, expressed as a fraction of the previous run.
+func (c Comparison) Regressed(threshold float64) bool {
+	return c.Previous != nil && c.Delta() > threshold
+}
+
+// Compare pairs every current result with the latest result in history for
+// the same day, part and input.
+func Compare(history, current []Result) []Comparison {
+	type key struct {
+		day       int
+		part      aoc.Part
+		inputHash string
+	}
+
+	latest := map[key]Result{}
+	for _, r := range history {
+		latest[key{r.Day, r.Part, r.InputHash}] = r
+	}
+
+	comparisons := make([]Comparison, 0, len(current))
+	for _, r := range current {
+		c := Comparison{Current: r}
+		if prev, found := latest[key{r.Day, r.Part, r.InputHash}]; found {
+			c.Previous = &prev
+		}
+
+		comparisons = append(comparisons, c)
+	}
+
+	return comparisons
+}
+
+// Report writes the comparisons as a table, flagging every part that
+// regressed by more than threshold. It returns the number of regressions.
+func Report(w io.Writer, comparisons []Comparison, threshold float64) (int, error) {
+	var (
+		tw          = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		regressions int
+	)
+
+	fmt.Fprintln(tw, "day\tpart\ttime/op\tallocs/op\tbytes/op\tprevious\tdelta\t")
+	for _, c := range comparisons {
+		previous, delta, flag := "-", "-", ""
+		if c.Previous != nil {
+			previous = time.Duration(c.Previous.NsPerOp).String()
+			delta = fmt.Sprintf("%+.1f%%", c.Delta()*100)
+		}
+
+		if c.Regressed(threshold) {
+			flag = "REGRESSION"
+			regressions++
+		}
+
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%d\t%d\t%s\t%s\t%s\n",
+			c.Current.Day,
+			c.Current.Part,
+			time.Duration(c.Current.NsPerOp),
+			c.Current.AllocsPerOp,
+			c.Current.BytesPerOp,
+			previous,
+			delta,
+			flag,
+		)
+	}
+
+	return regressions, tw.Flush()
+}
@@ -0,0 +1,61 @@
+package bench
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ReadHistory reads all results stored in the history file at path, oldest
+// first. A missing history file holds no results.
+func ReadHistory(path string) ([]Result, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var (
+		dec     = json.NewDecoder(f)
+		results []Result
+	)
+
+	for {
+		var r Result
+		err := dec.Decode(&r)
+		if errors.Is(err, io.EOF) {
+			return results, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to read history %s: %w", path, err)
+		}
+
+		results = append(results, r)
+	}
+}
+
+// AppendHistory appends results to the history file at path, a JSON object
+// per line, creating the file when it doesn't exist yet.
+func AppendHistory(path string, results []Result) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(f)
+	for _, r := range results {
+		if err := enc.Encode(r); err != nil {
+			f.Close()
+			return err
+		}
+	}
+
+	return f.Close()
+}
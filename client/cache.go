@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Fetcher fetches the puzzle input of a day, such as a Client.
+type Fetcher interface {
+	Input(ctx context.Context, year, day int) ([]byte, error)
+}
+
+// Cache keeps puzzle inputs on disk, fetching the ones it doesn't have yet.
+type Cache struct {
+	// Dir is the directory the inputs are stored in.
+	Dir string
+
+	// Fetcher fetches the inputs missing from the cache. Without one only
+	// cached inputs are available.
+	Fetcher Fetcher
+}
+
+// DefaultDir returns the directory inputs are cached in, which is AOC_CACHE_DIR
+// when set and a directory within the user's cache directory otherwise.
+func DefaultDir() (string, error) {
+	if dir := os.Getenv("AOC_CACHE_DIR"); dir != "" {
+		return dir, nil
+	}
+
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "aoc24"), nil
+}
+
+// Path returns the path the input of the given day is cached at.
+func (c *Cache) Path(year, day int) string {
+	return filepath.Join(c.Dir, fmt.Sprint(year), fmt.Sprintf("day_%02d.txt", day))
+}
+
+// Input returns the cached input of the given day, fetching and caching it
+// when it isn't cached yet.
+func (c *Cache) Input(ctx context.Context, year, day int) ([]byte, error) {
+	path, err := c.File(ctx, year, day)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.ReadFile(path)
+}
+
+// File returns the path of the cached input of the given day, fetching and
+// caching it when it isn't cached yet, so it can be read from disk as often
+// as needed.
+func (c *Cache) File(ctx context.Context, year, day int) (string, error) {
+	path := c.Path(year, day)
+
+	_, err := os.Stat(path)
+	if err == nil {
+		return path, nil
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+
+	if c.Fetcher == nil {
+		return "", fmt.Errorf("input of %d day %d isn't cached at %s", year, day, path)
+	}
+
+	input, err := c.Fetcher.Input(ctx, year, day)
+	if err != nil {
+		return "", err
+	}
+
+	if err := c.store(path, input); err != nil {
+		return "", fmt.Errorf("unable to cache input of %d day %d: %w", year, day, err)
+	}
+
+	return path, nil
+}
+
+// store writes input to path through a temporary file, so a partially
+// written input never ends up in the cache.
+func (c *Cache) store(path string, input []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), ".input-*")
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(input); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	// puzzle inputs are personal, keep them to the user
+	if err := os.Chmod(f.Name(), 0o600); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,121 @@
+// Package client talks to the Advent of Code website, fetching puzzle inputs
+// and keeping them in a local cache so they're only downloaded once.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultBaseURL is the address of the Advent of Code website.
+	DefaultBaseURL = "https://adventofcode.com"
+
+	// Year is the year of the puzzles solved in this repository.
+	Year = 2024
+
+	userAgent = "aoc24 (Go net/http)"
+
+	// timeout bounds a request of the default HTTP client, including
+	// reading the response.
+	timeout = 30 * time.Second
+)
+
+// defaultHTTPClient sends the requests of clients without an HTTP client of
+// their own. Unlike http.DefaultClient it gives up on a stalled server.
+var defaultHTTPClient = &http.Client{Timeout: timeout}
+
+// ErrNoSession is returned when an input has to be fetched without a
+// session token to authenticate with.
+var ErrNoSession = errors.New("no session token, set AOC_SESSION to the value of the session cookie")
+
+// Client fetches puzzle inputs over HTTP.
+type Client struct {
+	// BaseURL is the address the requests are sent to, DefaultBaseURL
+	// when empty.
+	BaseURL string
+
+	// Session is the value of the session cookie of a logged in user.
+	Session string
+
+	// HTTPClient sends the requests. When nil, a client giving up after 30
+	// seconds is used.
+	HTTPClient *http.Client
+}
+
+// FromEnv returns a client configured by the AOC_BASE_URL and AOC_SESSION
+// environment variables.
+func FromEnv() *Client {
+	return &Client{
+		BaseURL: os.Getenv("AOC_BASE_URL"),
+		Session: strings.TrimSpace(os.Getenv("AOC_SESSION")),
+	}
+}
+
+// Input fetches the puzzle input of the given day.
+func (c *Client) Input(ctx context.Context, year, day int) ([]byte, error) {
+	res, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/%d/day/%d/input", year, day), "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, statusError(res, year, day)
+	}
+
+	input, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read input of %d day %d: %w", year, day, err)
+	}
+
+	return input, nil
+}
+
+// do sends an authenticated request to path, relative to the base URL.
+func (c *Client) do(ctx context.Context, method, path, contentType string, body io.Reader) (*http.Response, error) {
+	if c.Session == "" {
+		return nil, ErrNoSession
+	}
+
+	base := c.BaseURL
+	if base == "" {
+		base = DefaultBaseURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(base, "/")+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	req.Header.Set("User-Agent", userAgent)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = defaultHTTPClient
+	}
+
+	return httpClient.Do(req)
+}
+
+// statusError describes an unexpected response, including the start of its
+// body which usually explains what went wrong.
+func statusError(res *http.Response, year, day int) error {
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 200))
+	msg := strings.TrimSpace(string(body))
+	if msg == "" {
+		return fmt.Errorf("%d day %d: unexpected response: %s", year, day, res.Status)
+	}
+
+	return fmt.Errorf("%d day %d: unexpected response: %s: %s", year, day, res.Status, msg)
+}
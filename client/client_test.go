@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func assert(t *testing.T, a, b any, msg string) {
+	t.Helper()
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("assertion failed: %s. Expected %+v, got %+v", msg, b, a)
+	}
+}
+
+// server stands in for the Advent of Code website, serving the input of
+// 2024 day 7 to the user with session "secret" and counting the requests.
+func server(t *testing.T) (*httptest.Server, *int) {
+	t.Helper()
+
+	var requests int
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /2024/day/7/input", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		cookie, err := r.Cookie("session")
+		if err != nil || cookie.Value != "secret" {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+
+		w.Write([]byte("190: 10 19\n"))
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return srv, &requests
+}
+
+func TestClient_Input(t *testing.T) {
+	srv, _ := server(t)
+	c := &Client{BaseURL: srv.URL + "/", Session: "secret"}
+
+	input, err := c.Input(context.Background(), 2024, 7)
+	assert(t, err, nil, "unexpected error")
+	assert(t, string(input), "190: 10 19\n", "incorrect input")
+}
+
+func TestClient_Input_Errors(t *testing.T) {
+	srv, _ := server(t)
+
+	_, err := (&Client{BaseURL: srv.URL}).Input(context.Background(), 2024, 7)
+	assert(t, errors.Is(err, ErrNoSession), true, "missing session")
+
+	_, err = (&Client{BaseURL: srv.URL, Session: "wrong"}).Input(context.Background(), 2024, 7)
+	assert(t, err != nil && strings.Contains(err.Error(), "400 Bad Request: Puzzle inputs differ by user"), true, "rejected session")
+
+	_, err = (&Client{BaseURL: srv.URL, Session: "secret"}).Input(context.Background(), 2024, 8)
+	assert(t, err != nil && strings.Contains(err.Error(), "404 Not Found"), true, "unknown day")
+}
+
+func TestClient_Input_Stalled(t *testing.T) {
+	assert(t, defaultHTTPClient.Timeout > 0, true, "default client should time out")
+
+	// a server that never answers
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	c := &Client{BaseURL: srv.URL, Session: "secret"}
+	_, err := c.Input(ctx, 2024, 7)
+	assert(t, errors.Is(err, context.DeadlineExceeded), true, "stalled request should be given up on")
+}
+
+func TestCache_Input(t *testing.T) {
+	var (
+		srv, requests = server(t)
+		cache         = Cache{
+			Dir:     t.TempDir(),
+			Fetcher: &Client{BaseURL: srv.URL, Session: "secret"},
+		}
+	)
+
+	for range 2 {
+		input, err := cache.Input(context.Background(), 2024, 7)
+		assert(t, err, nil, "unexpected error")
+		assert(t, string(input), "190: 10 19\n", "incorrect input")
+	}
+	assert(t, *requests, 1, "input should be fetched once")
+
+	path, err := cache.File(context.Background(), 2024, 7)
+	assert(t, err, nil, "unexpected error")
+	assert(t, path, cache.Path(2024, 7), "incorrect path of cached input")
+	assert(t, *requests, 1, "cached input shouldn't be fetched again")
+
+	cached, err := os.ReadFile(cache.Path(2024, 7))
+	assert(t, err, nil, "input should be cached")
+	assert(t, string(cached), "190: 10 19\n", "incorrect cached input")
+
+	info, err := os.Stat(cache.Path(2024, 7))
+	assert(t, err, nil, "unexpected error")
+	assert(t, info.Mode().Perm(), os.FileMode(0o600), "cached input should be private")
+
+	entries, err := os.ReadDir(cache.Dir + "/2024")
+	assert(t, err, nil, "unexpected error")
+	assert(t, len(entries), 1, "temporary files should be cleaned up")
+}
+
+func TestCache_Input_Errors(t *testing.T) {
+	var (
+		srv, _ = server(t)
+		cache  = Cache{
+			Dir:     t.TempDir(),
+			Fetcher: &Client{BaseURL: srv.URL, Session: "wrong"},
+		}
+	)
+
+	_, err := cache.Input(context.Background(), 2024, 7)
+	assert(t, err != nil, true, "fetch should fail")
+
+	_, err = os.Stat(cache.Path(2024, 7))
+	assert(t, errors.Is(err, os.ErrNotExist), true, "failed fetch shouldn't be cached")
+
+	cache.Fetcher = nil
+	_, err = cache.Input(context.Background(), 2024, 7)
+	assert(t, err != nil && strings.Contains(err.Error(), "isn't cached"), true, "missing input without fetcher")
+}
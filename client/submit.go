@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"aoc24/aoc"
+	"aoc24/ledger"
+)
+
+var (
+	// ErrTooRecent is returned when an answer is submitted before the
+	// timeout following a wrong answer has passed.
+	ErrTooRecent = errors.New("an answer was submitted too recently, wait before trying again")
+
+	// ErrAlreadySolved is returned when an answer is submitted for a part
+	// that's already solved or isn't unlocked yet.
+	ErrAlreadySolved = errors.New("part is already solved or not unlocked yet")
+)
+
+// Submit submits answer to the given part and returns the verdict.
+func (c *Client) Submit(ctx context.Context, year, day int, part aoc.Part, answer int) (ledger.Verdict, error) {
+	form := url.Values{
+		"level":  {fmt.Sprint(int(part))},
+		"answer": {fmt.Sprint(answer)},
+	}
+
+	res, err := c.do(ctx, http.MethodPost, fmt.Sprintf("/%d/day/%d/answer", year, day), "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", statusError(res, year, day)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", fmt.Errorf("unable to read response to answer of %d day %d: %w", year, day, err)
+	}
+
+	return parseVerdict(string(body))
+}
+
+// parseVerdict finds the verdict in the page returned after submitting an
+// answer.
+func parseVerdict(page string) (ledger.Verdict, error) {
+	switch {
+	case strings.Contains(page, "That's the right answer"):
+		return ledger.VerdictCorrect, nil
+
+	case strings.Contains(page, "your answer is too high"):
+		return ledger.VerdictTooHigh, nil
+
+	case strings.Contains(page, "your answer is too low"):
+		return ledger.VerdictTooLow, nil
+
+	case strings.Contains(page, "That's not the right answer"):
+		return ledger.VerdictWrong, nil
+
+	case strings.Contains(page, "You gave an answer too recently"):
+		return "", ErrTooRecent
+
+	case strings.Contains(page, "You don't seem to be solving the right level"):
+		return "", ErrAlreadySolved
+	}
+
+	return "", errors.New("unable to find the verdict in the response")
+}
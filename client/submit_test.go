@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"aoc24/aoc"
+	"aoc24/ledger"
+)
+
+// answerServer stands in for the answer form of 2024 day 15, of which the
+// answer to part two is 1000. Part one is already solved.
+func answerServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /2024/day/15/answer", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("level") != "2" {
+			fmt.Fprint(w, "<article><p>You don't seem to be solving the right level.  Did you already complete it?</p></article>")
+			return
+		}
+
+		answer, err := strconv.Atoi(r.FormValue("answer"))
+		switch {
+		case err != nil:
+			fmt.Fprint(w, "<article><p>That's not the right answer.</p></article>")
+
+		case answer == 0:
+			fmt.Fprint(w, "<article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.</p></article>")
+
+		case answer > 1000:
+			fmt.Fprint(w, "<article><p>That's not the right answer; your answer is too high.</p></article>")
+
+		case answer < 1000:
+			fmt.Fprint(w, "<article><p>That's not the right answer; your answer is too low.</p></article>")
+
+		default:
+			fmt.Fprint(w, "<article><p>That's the right answer!  You are one gold star closer to finding the Chief Historian.</p></article>")
+		}
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestClient_Submit(t *testing.T) {
+	var (
+		srv = answerServer(t)
+		c   = &Client{BaseURL: srv.URL, Session: "secret"}
+	)
+
+	tests := []struct {
+		part    aoc.Part
+		answer  int
+		verdict ledger.Verdict
+		err     error
+	}{
+		{aoc.PartTwo, 1488820, ledger.VerdictTooHigh, nil},
+		{aoc.PartTwo, 12, ledger.VerdictTooLow, nil},
+		{aoc.PartTwo, 1000, ledger.VerdictCorrect, nil},
+		{aoc.PartTwo, 0, "", ErrTooRecent},
+		{aoc.PartOne, 1000, "", ErrAlreadySolved},
+	}
+
+	for _, test := range tests {
+		verdict, err := c.Submit(context.Background(), 2024, 15, test.part, test.answer)
+		assert(t, errors.Is(err, test.err), true, fmt.Sprintf("error submitting %d: %v", test.answer, err))
+		assert(t, verdict, test.verdict, fmt.Sprintf("verdict of %d", test.answer))
+	}
+}
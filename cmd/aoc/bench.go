@@ -0,0 +1,95 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"aoc24/aoc"
+	"aoc24/bench"
+)
+
+func benchmark(args []string) error {
+	var (
+		fs          = flag.NewFlagSet("bench", flag.ExitOnError)
+		day         = fs.Int("day", 0, "day to benchmark, benchmarks all days when omitted")
+		part        = fs.Int("part", 0, "part to benchmark (1 or 2), benchmarks both when omitted")
+		inputPath   = fs.String("input", "", "path to the puzzle input, defaults to day_NN/input.txt")
+		historyPath = fs.String("history", "bench.ndjson", "file keeping the results of previous runs")
+		threshold   = fs.Float64("threshold", 0.1, "slowdown compared to the previous run that counts as a regression")
+		save        = fs.Bool("save", true, "append the results to the history file")
+	)
+	fs.Parse(args)
+
+	days := aoc.Days()
+	if *day != 0 {
+		if _, found := aoc.Lookup(*day); !found {
+			return fmt.Errorf("no solver registered for day %d", *day)
+		}
+
+		days = []int{*day}
+	} else if *inputPath != "" {
+		return errors.New("-input requires -day")
+	}
+
+	parts := aoc.Parts
+	if *part != 0 {
+		parts = []aoc.Part{aoc.Part(*part)}
+	}
+
+	var results []bench.Result
+	for _, d := range days {
+		path := *inputPath
+		if path == "" {
+			path = fmt.Sprintf("day_%02d/input.txt", d)
+		}
+
+		// unlike run, the input is held in memory, so reading it from disk
+		// doesn't end up in the measurements
+		input, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) && *day == 0 {
+			fmt.Fprintf(os.Stderr, "skipping day %d: no input at %s\n", d, path)
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		for _, p := range parts {
+			result, err := bench.Run(d, p, input)
+			if errors.Is(err, aoc.ErrNotImplemented) {
+				continue
+			}
+
+			if err != nil {
+				return fmt.Errorf("day %d %s: %w", d, p, err)
+			}
+
+			results = append(results, result)
+		}
+	}
+
+	history, err := bench.ReadHistory(*historyPath)
+	if err != nil {
+		return err
+	}
+
+	regressions, err := bench.Report(os.Stdout, bench.Compare(history, results), *threshold)
+	if err != nil {
+		return err
+	}
+
+	if *save {
+		if err := bench.AppendHistory(*historyPath, results); err != nil {
+			return err
+		}
+	}
+
+	if regressions > 0 {
+		return fmt.Errorf("%d parts regressed by more than %.0f%%", regressions, *threshold*100)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"aoc24/aoc"
+	"aoc24/parse"
+)
+
+// inputError is returned when a solver rejects its input. It holds on to the
+// offending line so it can be shown to the user.
+type inputError struct {
+	day  int
+	part aoc.Part
+	err  *parse.Error
+	line string
+
+	// found tells whether the input holds the line the error points at.
+	found bool
+}
+
+func (e *inputError) Error() string {
+	return fmt.Sprintf("day %d %s: invalid input: %v", e.day, e.part, e.err)
+}
+
+func (e *inputError) Unwrap() error {
+	return e.err
+}
+
+// Diagnose writes the offending line of the input, pointing out the text
+// that couldn't be parsed:
+//
+//	  |
+//	3 | 190: 10 x9
+//	  |         ^^ not an integer
+func (e *inputError) Diagnose(w io.Writer) {
+	if !e.found {
+		return
+	}
+
+	var (
+		line   = e.line
+		gutter = fmt.Sprint(e.err.Line)
+		pad    = strings.Repeat(" ", len(gutter))
+		col    = min(max(e.err.Column, 1), len(line)+1)
+		width  = max(min(len(e.err.Text), len(line)-col+1), 1)
+	)
+
+	// keep tabs in front of the offending text so the markers line up
+	indent := []byte(line[:col-1])
+	for idx, c := range indent {
+		if c != '\t' {
+			indent[idx] = ' '
+		}
+	}
+
+	fmt.Fprintf(w, "%s |\n", pad)
+	fmt.Fprintf(w, "%s | %s\n", gutter, line)
+	fmt.Fprintf(w, "%s | %s%s %v\n", pad, indent, strings.Repeat("^", width), e.err.Err)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"aoc24/parse"
+)
+
+func TestInputError_Diagnose(t *testing.T) {
+	tcs := []struct {
+		name     string
+		input    string
+		err      *parse.Error
+		expected string
+	}{
+		{
+			name:  "first line",
+			input: "x9: 10 19\n3267: 81 40 27\n",
+			err:   parse.Errorf(1, 1, "x9", "not an integer"),
+			expected: "" +
+				"  |\n" +
+				"1 | x9: 10 19\n" +
+				"  | ^^ not an integer\n",
+		},
+		{
+			name:  "past the end of the line",
+			input: "190: 10 19\n3267:\n",
+			err:   parse.Errorf(2, 12, "", "expected parts"),
+			expected: "" +
+				"  |\n" +
+				"2 | 3267:\n" +
+				"  |      ^ expected parts\n",
+		},
+		{
+			name:  "tabs",
+			input: "190:\t10\tx9\n",
+			err:   parse.Errorf(1, 9, "x9", "not an integer"),
+			expected: "" +
+				"  |\n" +
+				"1 | 190:\t10\tx9\n" +
+				"  |     \t  \t^^ not an integer\n",
+		},
+		{
+			name:  "crlf",
+			input: "190: 10 19\r\n3267: 81 x0 27\r\n",
+			err:   parse.Errorf(2, 10, "x0", "not an integer"),
+			expected: "" +
+				"  |\n" +
+				"2 | 3267: 81 x0 27\n" +
+				"  |          ^^ not an integer\n",
+		},
+		{
+			name:     "missing line",
+			input:    "190: 10 19\n",
+			err:      parse.Errorf(3, 1, "", "expected an equation"),
+			expected: "",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "input.txt")
+			assert(t, os.WriteFile(path, []byte(tc.input), 0o644), nil, "unexpected error")
+
+			in := &input{path: path}
+			e := &inputError{day: 7, err: tc.err}
+			e.line, e.found = in.line(tc.err.Line)
+
+			var b strings.Builder
+			e.Diagnose(&b)
+			assert(t, b.String(), tc.expected, "incorrect diagnosis")
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"aoc24/aoc"
+	"aoc24/client"
+)
+
+// do runs one of the extra commands of a day. The flags following the name
+// of the command are left to the command itself.
+func do(ctx context.Context, args []string) error {
+	var (
+		fs        = flag.NewFlagSet("do", flag.ExitOnError)
+		day       = fs.Int("day", 0, "day of the command")
+		year      = fs.Int("year", client.Year, "year of the puzzle, used to look up its input")
+		inputPath = fs.String("input", "", "path to the puzzle input or - for stdin, fetches and caches the input when omitted")
+	)
+	fs.Parse(args)
+
+	if _, found := aoc.Lookup(*day); !found {
+		return fmt.Errorf("no solver registered for day %d", *day)
+	}
+
+	if fs.NArg() == 0 {
+		return fmt.Errorf("no command given, day %d has %s", *day, commandNames(*day))
+	}
+
+	c, found := aoc.LookupCommand(*day, fs.Arg(0))
+	if !found {
+		return fmt.Errorf("unknown command %q, day %d has %s", fs.Arg(0), *day, commandNames(*day))
+	}
+
+	in, err := openInput(ctx, *inputPath, *year, *day)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	f, err := in.Open()
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return c.Run(f, os.Stdout, fs.Args()[1:])
+}
+
+func commandNames(day int) string {
+	cmds := aoc.Commands(day)
+	if len(cmds) == 0 {
+		return "no commands"
+	}
+
+	names := "commands:"
+	for _, c := range cmds {
+		names += fmt.Sprintf("\n  %-10s%s", c.Name, c.Summary)
+	}
+
+	return names
+}
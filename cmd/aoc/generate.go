@@ -0,0 +1,26 @@
+package main
+
+import (
+	"flag"
+	"os"
+
+	"aoc24/aoc"
+)
+
+func generateInput(args []string) error {
+	var (
+		fs   = flag.NewFlagSet("generate", flag.ExitOnError)
+		day  = fs.Int("day", 0, "day to generate an input for")
+		size = fs.Int("size", 100, "size of the input, its meaning depends on the day")
+		seed = fs.Uint64("seed", 1, "seed of the random source, the same seed produces the same input")
+	)
+	fs.Parse(args)
+
+	input, err := aoc.Generate(*day, *seed, *size)
+	if err != nil {
+		return err
+	}
+
+	_, err = os.Stdout.Write(input)
+	return err
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"strings"
+
+	"aoc24/client"
+)
+
+// input is a puzzle input on disk. It's opened again for every part, so a
+// solver only holds as much of it in memory as it needs.
+type input struct {
+	path string
+
+	// temp tells whether the input was copied from stdin into a temporary
+	// file, which is removed by Close.
+	temp bool
+}
+
+// openInput locates the input at path, or - for stdin. Without a path the
+// input is taken from the cache, which fetches it when it isn't cached yet
+// unless ctx is done first.
+func openInput(ctx context.Context, path string, year, day int) (*input, error) {
+	switch path {
+	case "":
+		dir, err := client.DefaultDir()
+		if err != nil {
+			return nil, err
+		}
+
+		cache := client.Cache{Dir: dir, Fetcher: client.FromEnv()}
+		path, err := cache.File(ctx, year, day)
+		if err != nil {
+			return nil, err
+		}
+
+		return &input{path: path}, nil
+
+	case "-":
+		return spool(os.Stdin)
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+
+	return &input{path: path}, nil
+}
+
+// spool copies r into a temporary file, as stdin can only be read once.
+func spool(r io.Reader) (*input, error) {
+	f, err := os.CreateTemp("", "aoc-input-*")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return nil, err
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return nil, err
+	}
+
+	return &input{path: f.Name(), temp: true}, nil
+}
+
+// Open opens the input for reading from the start.
+func (in *input) Open() (*os.File, error) {
+	return os.Open(in.path)
+}
+
+// Close removes the temporary copy of stdin.
+func (in *input) Close() error {
+	if in.temp {
+		return os.Remove(in.path)
+	}
+
+	return nil
+}
+
+// line returns the n-th line of the input, counting from 1, without its line
+// ending. It reports false when the input has fewer lines.
+func (in *input) line(n int) (string, bool) {
+	f, err := in.Open()
+	if err != nil {
+		return "", false
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	for idx := 1; ; idx++ {
+		text, err := r.ReadString('\n')
+		if idx == n && (err == nil || len(text) > 0) {
+			return strings.TrimSuffix(strings.TrimSuffix(text, "\n"), "\r"), true
+		}
+
+		if err != nil {
+			return "", false
+		}
+	}
+}
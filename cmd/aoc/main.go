@@ -0,0 +1,92 @@
+// Command aoc runs the solvers of all registered days.
+//
+// Usage:
+//
+//	aoc run -day 7 [-year 2024] [-part 2] [-input input.txt|-] [-format text|json|ndjson]
+//	        [-cpuprofile cpu.out] [-memprofile mem.out] [-trace trace.out] [-top 10]
+//	aoc do -day 1 [-year 2024] [-input input.txt|-] <command> [flags]
+//	aoc submit -day 7 -part 2 [-year 2024] [-answer 42] [-force]
+//	aoc record -day 7 -part 2 [-year 2024] -answer 42 -verdict too-high
+//	aoc bench [-day 7] [-part 2] [-input input.txt] [-history bench.ndjson] [-threshold 0.1]
+//	aoc generate -day 7 [-size 100] [-seed 1]
+//	aoc new <day>
+//	aoc list
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+
+	_ "aoc24/days"
+)
+
+const usage = `usage: aoc <command> [flags]
+
+commands:
+  run       solve a day's puzzle
+  do        run one of the extra commands of a day
+  submit    submit an answer and record its verdict
+  record    record the verdict of an answer submitted by hand
+  bench     benchmark the solvers and compare against the previous run
+  generate  generate a random input for a day
+  new       generate the skeleton of a new day
+  list      list all registered days
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	// interrupting cancels requests to the website instead of waiting for
+	// them to time out
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+
+	var err error
+	switch os.Args[1] {
+	case "run":
+		err = run(ctx, os.Args[2:])
+
+	case "do":
+		err = do(ctx, os.Args[2:])
+
+	case "submit":
+		err = submit(ctx, os.Args[2:])
+
+	case "record":
+		err = record(os.Args[2:])
+
+	case "bench":
+		err = benchmark(os.Args[2:])
+
+	case "generate":
+		err = generateInput(os.Args[2:])
+
+	case "new":
+		err = newDay(os.Args[2:])
+
+	case "list":
+		err = list()
+
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	stop()
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "aoc: %v\n", err)
+
+		var ierr *inputError
+		if errors.As(err, &ierr) {
+			ierr.Diagnose(os.Stderr)
+		}
+
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// scaffold lists the files generated for a new day, mapping the template to
+// the name of the file within the day's directory.
+var scaffold = []struct {
+	template string
+	name     string
+}{
+	{"main.go.tmpl", "main.go"},
+	{"main_test.go.tmpl", "main_test.go"},
+	{"generate.go.tmpl", "generate.go"},
+	{"README.md.tmpl", "README.md"},
+	{"Makefile.tmpl", "Makefile"},
+}
+
+type scaffoldData struct {
+	Day     int
+	Package string
+	Dir     string
+}
+
+func newDay(args []string) error {
+	var (
+		fs   = flag.NewFlagSet("new", flag.ExitOnError)
+		root = fs.String("root", ".", "root of the repository")
+	)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: aoc new [-root dir] <day>")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("expected a single day")
+	}
+
+	day, err := strconv.Atoi(fs.Arg(0))
+	if err != nil || day < 1 || day > 25 {
+		return fmt.Errorf("invalid day %q, expected a number from 1 to 25", fs.Arg(0))
+	}
+
+	return generate(*root, day)
+}
+
+// generate scaffolds the directory of a new day, registers it in package
+// days and adds its example to the golden answers. Everything is rendered
+// before anything is written, and a failure halfway removes the directory
+// and restores package days, so generating can simply be retried.
+func generate(root string, day int) (err error) {
+	data := scaffoldData{
+		Day:     day,
+		Package: fmt.Sprintf("day%02d", day),
+		Dir:     fmt.Sprintf("day_%02d", day),
+	}
+
+	tmpl, err := template.ParseFS(templates, "templates/*.tmpl")
+	if err != nil {
+		return err
+	}
+
+	files := map[string][]byte{
+		// an empty example, waiting for the one from the puzzle description
+		"example.txt": nil,
+	}
+
+	for _, f := range scaffold {
+		var b bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&b, f.template, data); err != nil {
+			return err
+		}
+
+		files[f.name] = b.Bytes()
+	}
+
+	daysPath := filepath.Join(root, "days", "days.go")
+	days, err := os.ReadFile(daysPath)
+	if err != nil {
+		return err
+	}
+
+	registered, err := registerDay(daysPath, days, data.Dir)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(root, data.Dir)
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			os.RemoveAll(dir)
+			os.WriteFile(daysPath, days, 0o644)
+		}
+	}()
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0o644); err != nil {
+			return err
+		}
+	}
+
+	if err := os.WriteFile(daysPath, registered, 0o644); err != nil {
+		return err
+	}
+
+	return appendExpectation(filepath.Join(root, "answers.txt"), day, data.Dir+"/example.txt")
+}
+
+// registerDay adds the blank import of a day's package to the import block
+// of src, the source of package days read from path, keeping the imports
+// sorted.
+func registerDay(path string, src []byte, dir string) ([]byte, error) {
+	before, rest, found := strings.Cut(string(src), "import (\n")
+	if !found {
+		return nil, fmt.Errorf("%s: no import block found", path)
+	}
+
+	block, after, found := strings.Cut(rest, ")")
+	if !found {
+		return nil, fmt.Errorf("%s: import block isn't closed", path)
+	}
+
+	imports := strings.Split(strings.TrimSpace(block), "\n")
+	for idx := range imports {
+		imports[idx] = strings.TrimSpace(imports[idx])
+	}
+
+	imp := fmt.Sprintf("_ %q", "aoc24/"+dir)
+	if slices.Contains(imports, imp) {
+		return nil, fmt.Errorf("%s is already registered", dir)
+	}
+
+	imports = append(imports, imp)
+	slices.Sort(imports)
+
+	return format.Source([]byte(before + "import (\n" + strings.Join(imports, "\n") + "\n)" + after))
+}
+
+// appendExpectation adds an entry without known answers for input to the
+// answers file.
+func appendExpectation(path string, day int, input string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(f, "%-6d %-26s %-9s %s\n", day, input, "-", "-"); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"go/format"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func assert(t *testing.T, a, b any, msg string) {
+	t.Helper()
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("assertion failed: %s. Expected %+v, got %+v", msg, b, a)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	root := t.TempDir()
+
+	days := "// Package days imports every day.\npackage days\n\nimport (\n\t_ \"aoc24/day_01\"\n\t_ \"aoc24/day_20\"\n)\n"
+	assert(t, os.Mkdir(filepath.Join(root, "days"), 0o755), nil, "unexpected error")
+	assert(t, os.WriteFile(filepath.Join(root, "days", "days.go"), []byte(days), 0o644), nil, "unexpected error")
+	assert(t, os.WriteFile(filepath.Join(root, "answers.txt"), []byte("1 day_01/example.txt 11 31\n"), 0o644), nil, "unexpected error")
+
+	assert(t, generate(root, 7), nil, "unexpected error")
+
+	for _, name := range []string{"main.go", "main_test.go", "generate.go", "README.md", "Makefile", "example.txt"} {
+		_, err := os.Stat(filepath.Join(root, "day_07", name))
+		assert(t, err, nil, "expected "+name+" to be generated")
+	}
+
+	for _, name := range []string{"main.go", "main_test.go", "generate.go"} {
+		src, err := os.ReadFile(filepath.Join(root, "day_07", name))
+		assert(t, err, nil, "unexpected error")
+		assert(t, strings.HasPrefix(string(src), "package day07\n"), true, "incorrect package in "+name)
+
+		formatted, err := format.Source(src)
+		assert(t, err, nil, "invalid source in "+name)
+		assert(t, string(formatted), string(src), name+" isn't formatted")
+	}
+
+	src, err := os.ReadFile(filepath.Join(root, "day_07", "main.go"))
+	assert(t, err, nil, "unexpected error")
+	assert(t, strings.Contains(string(src), "aoc.Register(7, Solver{})"), true, "day isn't registered")
+
+	src, err = os.ReadFile(filepath.Join(root, "day_07", "main_test.go"))
+	assert(t, err, nil, "unexpected error")
+	assert(t, strings.Contains(string(src), "aoctest.FuzzSeeds(f, generate)"), true, "fuzz test isn't seeded")
+
+	registered, err := os.ReadFile(filepath.Join(root, "days", "days.go"))
+	assert(t, err, nil, "unexpected error")
+	assert(t, string(registered), "// Package days imports every day.\npackage days\n\nimport (\n\t_ \"aoc24/day_01\"\n\t_ \"aoc24/day_07\"\n\t_ \"aoc24/day_20\"\n)\n", "incorrect imports")
+
+	answers, err := os.ReadFile(filepath.Join(root, "answers.txt"))
+	assert(t, err, nil, "unexpected error")
+	assert(t, strings.Fields(strings.Split(string(answers), "\n")[1]), []string{"7", "day_07/example.txt", "-", "-"}, "incorrect expectation")
+
+	t.Run("existing day", func(t *testing.T) {
+		assert(t, generate(root, 7) != nil, true, "expected error generating existing day")
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		// without an answers file the last step fails, which should leave
+		// nothing behind so the day can be generated once it's back
+		assert(t, os.Rename(filepath.Join(root, "answers.txt"), filepath.Join(root, "answers.bak")), nil, "unexpected error")
+		assert(t, generate(root, 8) != nil, true, "expected error without answers file")
+
+		_, err := os.Stat(filepath.Join(root, "day_08"))
+		assert(t, errors.Is(err, os.ErrNotExist), true, "directory of failed day should be removed")
+
+		restored, err := os.ReadFile(filepath.Join(root, "days", "days.go"))
+		assert(t, err, nil, "unexpected error")
+		assert(t, string(restored), string(registered), "days should be restored")
+
+		assert(t, os.Rename(filepath.Join(root, "answers.bak"), filepath.Join(root, "answers.txt")), nil, "unexpected error")
+		assert(t, generate(root, 8), nil, "retrying should succeed")
+	})
+}
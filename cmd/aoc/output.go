@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"aoc24/aoc"
+)
+
+// output writes the results of a run in one of the supported formats.
+type output interface {
+	// Result writes a solved part.
+	Result(r aoc.Result) error
+
+	// NotImplemented notes a part that has no solution yet.
+	NotImplemented(day int, part aoc.Part) error
+
+	// Close flushes any results that haven't been written yet.
+	Close() error
+}
+
+// Statuses of the parts in the json and ndjson formats.
+const (
+	statusSolved         = "solved"
+	statusNotImplemented = "not-implemented"
+)
+
+// partRecord is a part in the json and ndjson formats. Parts that aren't
+// implemented only hold the day, the part and the status.
+type partRecord struct {
+	Day    int      `json:"day"`
+	Part   aoc.Part `json:"part"`
+	Status string   `json:"status"`
+
+	*aoc.Result
+}
+
+func solvedRecord(r aoc.Result) partRecord {
+	return partRecord{Day: r.Day, Part: r.Part, Status: statusSolved, Result: &r}
+}
+
+func notImplementedRecord(day int, part aoc.Part) partRecord {
+	return partRecord{Day: day, Part: part, Status: statusNotImplemented}
+}
+
+func newOutput(format string, w io.Writer) (output, error) {
+	switch format {
+	case "text":
+		return &textOutput{w: w}, nil
+
+	case "json":
+		return &jsonOutput{w: w, records: []partRecord{}}, nil
+
+	case "ndjson":
+		return &ndjsonOutput{enc: json.NewEncoder(w)}, nil
+	}
+
+	return nil, fmt.Errorf("unknown output format %q, expected text, json or ndjson", format)
+}
+
+// textOutput writes a line per part, meant to be read by humans.
+type textOutput struct {
+	w io.Writer
+}
+
+func (o *textOutput) Result(r aoc.Result) error {
+	_, err := fmt.Fprintf(o.w, "day %d %s = %d (took %+v)\n", r.Day, r.Part, r.Answer, r.Duration)
+	return err
+}
+
+func (o *textOutput) NotImplemented(day int, part aoc.Part) error {
+	_, err := fmt.Fprintf(o.w, "day %d %s = not implemented\n", day, part)
+	return err
+}
+
+func (o *textOutput) Close() error {
+	return nil
+}
+
+// jsonOutput collects all records and writes them as a single JSON array
+// once the run completes.
+type jsonOutput struct {
+	w       io.Writer
+	records []partRecord
+}
+
+func (o *jsonOutput) Result(r aoc.Result) error {
+	o.records = append(o.records, solvedRecord(r))
+	return nil
+}
+
+func (o *jsonOutput) NotImplemented(day int, part aoc.Part) error {
+	o.records = append(o.records, notImplementedRecord(day, part))
+	return nil
+}
+
+func (o *jsonOutput) Close() error {
+	enc := json.NewEncoder(o.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(o.records)
+}
+
+// ndjsonOutput writes every record as a JSON object on a line of its own as
+// soon as its part is done.
+type ndjsonOutput struct {
+	enc *json.Encoder
+}
+
+func (o *ndjsonOutput) Result(r aoc.Result) error {
+	return o.enc.Encode(solvedRecord(r))
+}
+
+func (o *ndjsonOutput) NotImplemented(day int, part aoc.Part) error {
+	return o.enc.Encode(notImplementedRecord(day, part))
+}
+
+func (o *ndjsonOutput) Close() error {
+	return nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"aoc24/aoc"
+)
+
+func TestOutput(t *testing.T) {
+	result := aoc.Result{
+		Day:       7,
+		Part:      aoc.PartOne,
+		Answer:    3749,
+		Duration:  1500 * time.Microsecond,
+		InputHash: "ab12",
+	}
+
+	tcs := []struct {
+		format   string
+		expected string
+	}{
+		{"text", "" +
+			"day 7 part one = 3749 (took 1.5ms)\n" +
+			"day 7 part two = not implemented\n",
+		},
+		{"json", `[
+  {
+    "day": 7,
+    "part": 1,
+    "status": "solved",
+    "answer": 3749,
+    "duration": 1500000,
+    "input_hash": "ab12"
+  },
+  {
+    "day": 7,
+    "part": 2,
+    "status": "not-implemented"
+  }
+]
+`,
+		},
+		{"ndjson", "" +
+			`{"day":7,"part":1,"status":"solved","answer":3749,"duration":1500000,"input_hash":"ab12"}` + "\n" +
+			`{"day":7,"part":2,"status":"not-implemented"}` + "\n",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.format, func(t *testing.T) {
+			var b strings.Builder
+			out, err := newOutput(tc.format, &b)
+			assert(t, err, nil, "unexpected error")
+
+			assert(t, out.Result(result), nil, "unexpected error writing result")
+			assert(t, out.NotImplemented(7, aoc.PartTwo), nil, "unexpected error writing missing part")
+			assert(t, out.Close(), nil, "unexpected error closing")
+			assert(t, b.String(), tc.expected, "incorrect output")
+		})
+	}
+
+	t.Run("empty json", func(t *testing.T) {
+		var b strings.Builder
+		out, _ := newOutput("json", &b)
+		assert(t, out.Close(), nil, "unexpected error closing")
+		assert(t, b.String(), "[]\n", "an empty run should be an empty array")
+	})
+
+	_, err := newOutput("yaml", &strings.Builder{})
+	assert(t, err != nil, true, "unknown format should be rejected")
+}
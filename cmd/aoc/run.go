@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"aoc24/aoc"
+	"aoc24/client"
+	"aoc24/ledger"
+	"aoc24/parse"
+	"aoc24/prof"
+)
+
+func run(ctx context.Context, args []string) error {
+	var (
+		fs        = flag.NewFlagSet("run", flag.ExitOnError)
+		day       = fs.Int("day", 0, "day to solve")
+		part      = fs.Int("part", 0, "part to solve (1 or 2), solves both when omitted")
+		year      = fs.Int("year", client.Year, "year of the puzzle, used to look up its input")
+		inputPath = fs.String("input", "", "path to the puzzle input or - for stdin, fetches and caches the input when omitted")
+		format    = fs.String("format", "text", "output format: text, json or ndjson")
+		profiling prof.Options
+		top       = fs.Int("top", 10, "number of functions to list in the summary of each profile")
+	)
+	fs.StringVar(&profiling.CPUProfile, "cpuprofile", "", "write a CPU profile to this file")
+	fs.StringVar(&profiling.MemProfile, "memprofile", "", "write a memory profile to this file")
+	fs.StringVar(&profiling.Trace, "trace", "", "write an execution trace to this file")
+	fs.Parse(args)
+
+	if _, found := aoc.Lookup(*day); !found {
+		return fmt.Errorf("no solver registered for day %d", *day)
+	}
+
+	out, err := newOutput(*format, os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	parts := aoc.Parts
+	if *part != 0 {
+		parts = []aoc.Part{aoc.Part(*part)}
+	}
+
+	in, err := openInput(ctx, *inputPath, *year, *day)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	// answers submitted before only apply to the cached input
+	var l *ledger.Ledger
+	if *inputPath == "" {
+		if l, err = openLedger(*year, *day); err != nil {
+			return err
+		}
+	}
+
+	stop, err := prof.Start(profiling)
+	if err != nil {
+		return err
+	}
+
+	if err := solve(out, *day, parts, in, l); err != nil {
+		stop()
+		return err
+	}
+
+	if err := stop(); err != nil {
+		return err
+	}
+
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	return summarize(profiling, *top)
+}
+
+// solve solves the parts of day and writes their results to out, warning
+// about answers known to be wrong when a ledger is given.
+func solve(out output, day int, parts []aoc.Part, in *input, l *ledger.Ledger) error {
+	for _, p := range parts {
+		result, err := runPart(day, p, in)
+		if errors.Is(err, aoc.ErrNotImplemented) {
+			if err := out.NotImplemented(day, p); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		var perr *parse.Error
+		if errors.As(err, &perr) {
+			ierr := &inputError{day: day, part: p, err: perr}
+			ierr.line, ierr.found = in.line(perr.Line)
+			return ierr
+		}
+
+		if err != nil {
+			return fmt.Errorf("day %d %s: %w", day, p, err)
+		}
+
+		if l != nil {
+			warnConflicts(l, result)
+		}
+
+		if err := out.Result(result); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// summarize writes the top functions of the profiles that were written to
+// stderr, keeping stdout free for the results.
+func summarize(profiling prof.Options, top int) error {
+	profiles := []struct {
+		path       string
+		sampleType string
+	}{
+		{profiling.CPUProfile, "cpu"},
+		{profiling.MemProfile, "alloc_space"},
+	}
+
+	for _, p := range profiles {
+		if p.path == "" || top <= 0 {
+			continue
+		}
+
+		summary, err := prof.Summarize(p.path, p.sampleType, top)
+		if err != nil {
+			return fmt.Errorf("unable to summarize %s: %w", p.path, err)
+		}
+
+		fmt.Fprintf(os.Stderr, "\n%s\n", p.path)
+		if err := summary.Write(os.Stderr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func list() error {
+	for _, day := range aoc.Days() {
+		fmt.Printf("day %d\n", day)
+		for _, c := range aoc.Commands(day) {
+			fmt.Printf("  %-10s%s\n", c.Name, c.Summary)
+		}
+	}
+
+	return nil
+}
+
+// runPart solves a single part, streaming the input from disk.
+func runPart(day int, p aoc.Part, in *input) (aoc.Result, error) {
+	f, err := in.Open()
+	if err != nil {
+		return aoc.Result{}, err
+	}
+	defer f.Close()
+
+	return aoc.RunReader(day, p, f)
+}
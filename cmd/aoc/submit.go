@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"aoc24/aoc"
+	"aoc24/client"
+	"aoc24/ledger"
+)
+
+// submit submits an answer and records the verdict in the ledger of the day.
+// Without an answer the part is solved against the cached input first.
+func submit(ctx context.Context, args []string) error {
+	var (
+		fs     = flag.NewFlagSet("submit", flag.ExitOnError)
+		year   = fs.Int("year", client.Year, "year of the puzzle")
+		day    = fs.Int("day", 0, "day of the puzzle")
+		part   = fs.Int("part", 0, "part to submit the answer to (1 or 2)")
+		answer = fs.String("answer", "", "answer to submit, solves the part when omitted")
+		force  = fs.Bool("force", false, "submit answers the ledger knows to be wrong")
+	)
+	fs.Parse(args)
+
+	p := aoc.Part(*part)
+	if p != aoc.PartOne && p != aoc.PartTwo {
+		return fmt.Errorf("invalid part: %d", *part)
+	}
+
+	l, err := openLedger(*year, *day)
+	if err != nil {
+		return err
+	}
+
+	var a int
+	if *answer != "" {
+		if a, err = parseAnswer(*answer); err != nil {
+			return err
+		}
+	} else {
+		in, err := openInput(ctx, "", *year, *day)
+		if err != nil {
+			return err
+		}
+
+		result, err := runPart(*day, p, in)
+		if err != nil {
+			return fmt.Errorf("day %d %s: %w", *day, p, err)
+		}
+		a = result.Answer
+	}
+
+	// the site has nothing to add about an answer known to be correct
+	if correct, found := l.Correct(p); found && correct == a {
+		fmt.Printf("day %d %s: %d is %s, as recorded before\n", *day, p, a, ledger.VerdictCorrect)
+		return nil
+	}
+
+	if err := l.Check(p, a); err != nil && !*force {
+		return fmt.Errorf("refusing to submit, day %d %w", *day, err)
+	}
+
+	verdict, err := client.FromEnv().Submit(ctx, *year, *day, p, a)
+	if err != nil {
+		return err
+	}
+
+	if err := l.Record(ledger.Entry{Part: p, Answer: a, Verdict: verdict, Time: time.Now()}); err != nil {
+		return err
+	}
+
+	fmt.Printf("day %d %s: %d is %s\n", *day, p, a, verdict)
+	return nil
+}
+
+// record records the verdict of an answer that was submitted by hand.
+func record(args []string) error {
+	var (
+		fs      = flag.NewFlagSet("record", flag.ExitOnError)
+		year    = fs.Int("year", client.Year, "year of the puzzle")
+		day     = fs.Int("day", 0, "day of the puzzle")
+		part    = fs.Int("part", 0, "part the answer was submitted to (1 or 2)")
+		answer  = fs.String("answer", "", "answer that was submitted")
+		verdict = fs.String("verdict", "", "verdict of the answer: correct, too-high, too-low or wrong")
+	)
+	fs.Parse(args)
+
+	p := aoc.Part(*part)
+	if p != aoc.PartOne && p != aoc.PartTwo {
+		return fmt.Errorf("invalid part: %d", *part)
+	}
+
+	a, err := parseAnswer(*answer)
+	if err != nil {
+		return err
+	}
+
+	v, err := ledger.ParseVerdict(*verdict)
+	if err != nil {
+		return err
+	}
+
+	l, err := openLedger(*year, *day)
+	if err != nil {
+		return err
+	}
+
+	return l.Record(ledger.Entry{Part: p, Answer: a, Verdict: v, Time: time.Now()})
+}
+
+func parseAnswer(s string) (int, error) {
+	a, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid answer %q", s)
+	}
+
+	return a, nil
+}
+
+// openLedger opens the ledger of the given day, which is kept next to the
+// cached input as the answers are specific to it.
+func openLedger(year, day int) (*ledger.Ledger, error) {
+	if _, found := aoc.Lookup(day); !found {
+		return nil, fmt.Errorf("no solver registered for day %d", day)
+	}
+
+	dir, err := client.DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return ledger.Open(filepath.Join(dir, fmt.Sprint(year), fmt.Sprintf("day_%02d.answers.ndjson", day)))
+}
+
+// warnConflicts writes a warning to stderr when result is known to be wrong.
+func warnConflicts(l *ledger.Ledger, result aoc.Result) {
+	var conflict *ledger.ConflictError
+	if err := l.Check(result.Part, result.Answer); errors.As(err, &conflict) {
+		fmt.Fprintf(os.Stderr, "aoc: warning: day %d %v\n", result.Day, conflict)
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"aoc24/aoc"
+	"aoc24/ledger"
+)
+
+func TestSubmit_KnownCorrect(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte("<article><p>That's not the right answer.</p></article>"))
+	}))
+	t.Cleanup(srv.Close)
+
+	t.Setenv("AOC_CACHE_DIR", t.TempDir())
+	t.Setenv("AOC_BASE_URL", srv.URL)
+	t.Setenv("AOC_SESSION", "secret")
+
+	l, err := openLedger(2024, 7)
+	assert(t, err, nil, "unexpected error")
+	assert(t, l.Record(ledger.Entry{Part: aoc.PartOne, Answer: 3749, Verdict: ledger.VerdictCorrect, Time: time.Now()}), nil, "unexpected error")
+
+	err = submit(context.Background(), []string{"-day", "7", "-part", "1", "-answer", "3749"})
+	assert(t, err, nil, "unexpected error")
+	assert(t, requests, 0, "an answer known to be correct shouldn't be submitted again")
+
+	err = submit(context.Background(), []string{"-day", "7", "-part", "1", "-answer", "42"})
+	assert(t, err != nil, true, "a different answer than the correct one should be refused")
+	assert(t, requests, 0, "a refused answer shouldn't be submitted")
+}
@@ -0,0 +1,29 @@
+package day01
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+// generate produces two lists of size location ids. About half of the ids in
+// the right list also appear in the left list, so there's something to find
+// for the similarity score.
+func generate(rng *rand.Rand, size int) []byte {
+	left := make([]int, size)
+	for idx := range left {
+		left[idx] = 10000 + rng.IntN(90000)
+	}
+
+	var b strings.Builder
+	for _, l := range left {
+		r := 10000 + rng.IntN(90000)
+		if rng.IntN(2) == 0 {
+			r = left[rng.IntN(size)]
+		}
+
+		fmt.Fprintf(&b, "%d   %d\n", l, r)
+	}
+
+	return []byte(b.String())
+}
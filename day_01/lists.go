@@ -0,0 +1,170 @@
+package day01
+
+import (
+	"fmt"
+	"io"
+	"slices"
+
+	"aoc24/parse"
+)
+
+// ParseColumns parses an input of whitespace separated columns of integers,
+// returning the numbers of every column in the order they appear. Every line
+// must hold n numbers, or as many as the first line when n is 0. Empty lines
+// are skipped.
+func ParseColumns(input io.Reader, n int) ([][]int, error) {
+	var (
+		r       = parse.NewReader(input)
+		columns [][]int
+	)
+
+	for line := range r.Lines() {
+		fields := parse.Fields(line.Text)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if n == 0 {
+			n = len(fields)
+		}
+
+		if len(fields) != n {
+			return nil, parse.Errorf(line.Number, 1, line.Text, "expected %d numbers, found %d fields", n, len(fields))
+		}
+
+		if columns == nil {
+			columns = make([][]int, n)
+		}
+
+		for idx, field := range fields {
+			num, err := field.Int(line.Number)
+			if err != nil {
+				return nil, err
+			}
+
+			columns[idx] = append(columns[idx], num)
+		}
+	}
+
+	if err := r.Err(); err != nil {
+		return nil, err
+	}
+
+	return columns, nil
+}
+
+// pairs sorts copies of both lists and pairs them up, smallest with smallest.
+// The lists must be of the same length.
+func pairs(listA, listB []int) ([]int, []int) {
+	if len(listA) != len(listB) {
+		panic(fmt.Errorf("lists of different lengths: %d and %d", len(listA), len(listB)))
+	}
+
+	return slices.Sorted(slices.Values(listA)), slices.Sorted(slices.Values(listB))
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
+
+// Distance sums the distances between the numbers of both lists, pairing up
+// the smallest number of one list with the smallest of the other and so on.
+// The lists must be of the same length.
+func Distance(listA, listB []int) int {
+	sortedA, sortedB := pairs(listA, listB)
+
+	diff := 0
+	for idx := range sortedA {
+		diff += abs(sortedA[idx] - sortedB[idx])
+	}
+
+	return diff
+}
+
+// MedianDistance returns the median of the distances between the numbers of
+// both lists, paired up like they are for Distance. It's 0 for empty lists.
+func MedianDistance(listA, listB []int) float64 {
+	sortedA, sortedB := pairs(listA, listB)
+	if len(sortedA) == 0 {
+		return 0
+	}
+
+	dists := make([]int, len(sortedA))
+	for idx := range sortedA {
+		dists[idx] = abs(sortedA[idx] - sortedB[idx])
+	}
+	slices.Sort(dists)
+
+	mid := len(dists) / 2
+	if len(dists)%2 == 1 {
+		return float64(dists[mid])
+	}
+
+	return float64(dists[mid-1]+dists[mid]) / 2
+}
+
+// ExactMatches counts the pairs of numbers that are equal, paired up like
+// they are for Distance.
+func ExactMatches(listA, listB []int) int {
+	sortedA, sortedB := pairs(listA, listB)
+
+	matches := 0
+	for idx := range sortedA {
+		if sortedA[idx] == sortedB[idx] {
+			matches++
+		}
+	}
+
+	return matches
+}
+
+// Similarity adds up every number of listA multiplied by the number of times
+// it appears in listB.
+func Similarity(listA, listB []int) int {
+	freqMap := make(map[int]int)
+	for _, num := range listB {
+		freqMap[num]++
+	}
+
+	score := 0
+	for _, num := range listA {
+		score += num * freqMap[num]
+	}
+
+	return score
+}
+
+// Jaccard returns the Jaccard index of the distinct numbers of both lists:
+// the number of distinct numbers they share divided by the number of distinct
+// numbers in either. Two empty lists are identical, with an index of 1.
+func Jaccard(listA, listB []int) float64 {
+	var (
+		setA = make(map[int]bool, len(listA))
+		setB = make(map[int]bool, len(listB))
+	)
+
+	for _, num := range listA {
+		setA[num] = true
+	}
+
+	for _, num := range listB {
+		setB[num] = true
+	}
+
+	if len(setA) == 0 && len(setB) == 0 {
+		return 1
+	}
+
+	shared := 0
+	for num := range setA {
+		if setB[num] {
+			shared++
+		}
+	}
+
+	return float64(shared) / float64(len(setA)+len(setB)-shared)
+}
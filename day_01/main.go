@@ -1,63 +1,46 @@
-package main
+package day01
 
 import (
-	"fmt"
 	"io"
-	"math"
-	"os"
-	"slices"
-	"strings"
-)
-
-func main() {
-	input, err := io.ReadAll(os.Stdin)
-	if err != nil {
-		panic(err)
-	}
 
-	lines := strings.Split(string(input), "\n")
-	listA := make([]int, 0, len(lines))
-	listB := make([]int, 0, len(lines))
-
-	for line := range slices.Values(lines) {
-		if len(line) == 0 {
-			continue
-		}
-		var itemA, itemB int
-		_, err := fmt.Sscanf(line, "%d   %d", &itemA, &itemB)
-		if err != nil {
-			panic(fmt.Errorf("error scanning: %w", err))
-		}
-
-		listA = append(listA, itemA)
-		listB = append(listB, itemB)
-	}
+	"aoc24/aoc"
+)
 
-	slices.Sort(listA)
-	slices.Sort(listB)
-	diff := float64(0)
+func init() {
+	aoc.Register(1, Solver{})
+	aoc.RegisterGenerator(1, generate)
+	aoc.RegisterCommand(1, aoc.Command{
+		Name:    "matrix",
+		Summary: "compare every pair of columns by distance and similarity",
+		Run:     matrix,
+	})
+}
 
-	freqMap := make(map[int]int)
-	for _, num := range listB {
-		if _, ok := freqMap[num]; !ok {
-			freqMap[num] = 0
-		}
+type Solver struct{}
 
-		freqMap[num] += 1
+func (Solver) PartOne(input io.Reader) (int, error) {
+	listA, listB, err := parseInput(input)
+	if err != nil {
+		return 0, err
 	}
 
-	for idx := range listA {
-		left, right := listA[idx], listB[idx]
-		diff += math.Abs(float64(left - right))
+	return Distance(listA, listB), nil
+}
+
+func (Solver) PartTwo(input io.Reader) (int, error) {
+	listA, listB, err := parseInput(input)
+	if err != nil {
+		return 0, err
 	}
 
-	fmt.Printf("output Part One: %d\n", int(diff))
+	return Similarity(listA, listB), nil
+}
 
-	partB := 0
-	for _, num := range listA {
-		if multiplier, found := freqMap[num]; found {
-			partB += num * multiplier
-		}
+func parseInput(input io.Reader) ([]int, []int, error) {
+	columns, err := ParseColumns(input, 2)
+	if err != nil || columns == nil {
+		return nil, nil, err
 	}
-	fmt.Printf("output Part Two: %d\n", partB)
+
+	return columns[0], columns[1], nil
 }
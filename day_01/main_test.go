@@ -0,0 +1,107 @@
+package day01
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"aoc24/aoc"
+	"aoc24/aoc/aoctest"
+	"aoc24/parse"
+)
+
+func assert(t *testing.T, a, b any, msg string) {
+	t.Helper()
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("assertion failed: %s. Expected %+v, got %+v", msg, b, a)
+	}
+}
+
+var (
+	exampleA = []int{3, 4, 2, 1, 3, 3}
+	exampleB = []int{4, 3, 5, 3, 9, 3}
+)
+
+func TestMetrics(t *testing.T) {
+	assert(t, Distance(exampleA, exampleB), 11, "distance")
+	assert(t, Similarity(exampleA, exampleB), 31, "similarity")
+	assert(t, MedianDistance(exampleA, exampleB), 1.5, "median distance")
+	assert(t, MedianDistance(exampleA[:5], exampleB[:5]), 2.0, "median distance of an odd number of pairs")
+	assert(t, ExactMatches(exampleA, exampleB), 1, "exact matches")
+	assert(t, Jaccard(exampleA, exampleB), 2.0/6, "jaccard index")
+
+	assert(t, exampleA, []int{3, 4, 2, 1, 3, 3}, "lists shouldn't be sorted in place")
+}
+
+func TestMetrics_Empty(t *testing.T) {
+	assert(t, Distance(nil, nil), 0, "distance")
+	assert(t, Similarity(nil, nil), 0, "similarity")
+	assert(t, MedianDistance(nil, nil), 0.0, "median distance")
+	assert(t, ExactMatches(nil, nil), 0, "exact matches")
+	assert(t, Jaccard(nil, nil), 1.0, "jaccard index")
+	assert(t, Jaccard([]int{1}, nil), 0.0, "jaccard index against an empty list")
+}
+
+func TestParseColumns(t *testing.T) {
+	columns, err := ParseColumns(strings.NewReader("3   4\n4\t3\r\n\n  2 5  \n"), 2)
+	assert(t, err, nil, "unexpected error")
+	assert(t, columns, [][]int{{3, 4, 2}, {4, 3, 5}}, "incorrect columns")
+
+	columns, err = ParseColumns(strings.NewReader("1 2 3\n4 5 6\n"), 0)
+	assert(t, err, nil, "unexpected error")
+	assert(t, columns, [][]int{{1, 4}, {2, 5}, {3, 6}}, "number of columns should follow the first line")
+
+	columns, err = ParseColumns(strings.NewReader(""), 0)
+	assert(t, err, nil, "unexpected error")
+	assert(t, len(columns), 0, "empty input has no columns")
+
+	var perr *parse.Error
+	_, err = ParseColumns(strings.NewReader("1 2 3\n4 5\n"), 0)
+	assert(t, errors.As(err, &perr) && perr.Line == 2, true, "missing column should be reported on line 2")
+
+	_, err = ParseColumns(strings.NewReader("1 2\n4 x\n"), 2)
+	assert(t, errors.As(err, &perr) && perr.Line == 2 && perr.Column == 3, true, "invalid number should be reported at line 2, column 3")
+}
+
+func TestMatrices(t *testing.T) {
+	columns := [][]int{exampleA, exampleB, {1, 1, 1, 1, 1, 1}}
+
+	distance := DistanceMatrix(columns)
+	assert(t, distance, Matrix{{0, 11, 10}, {11, 0, 21}, {10, 21, 0}}, "incorrect distance matrix")
+
+	similarity := SimilarityMatrix(columns)
+	assert(t, similarity, Matrix{{34, 31, 6}, {31, 45, 0}, {6, 0, 36}}, "incorrect similarity matrix")
+
+	for i := range columns {
+		for j := range columns {
+			assert(t, distance[i][j], Distance(columns[i], columns[j]), "matrix should match Distance")
+			assert(t, similarity[i][j], Similarity(columns[i], columns[j]), "matrix should match Similarity")
+		}
+	}
+}
+
+func TestMatrixCommand(t *testing.T) {
+	c, found := aoc.LookupCommand(1, "matrix")
+	assert(t, found, true, "matrix command should be registered")
+
+	var out bytes.Buffer
+	err := c.Run(strings.NewReader("3 4\n4 3\n2 5\n"), &out, []string{"-format", "json"})
+	assert(t, err, nil, "unexpected error")
+	assert(t, out.String(), `{"distance":[[0,3],[3,0]],"similarity":[[9,7],[7,12]]}`+"\n", "incorrect json")
+
+	err = c.Run(strings.NewReader("3 4\n"), &out, []string{"-format", "xml"})
+	assert(t, err != nil, true, "unknown format should be rejected")
+}
+
+func FuzzParseInput(f *testing.F) {
+	aoctest.FuzzSeeds(f, generate)
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		listA, listB, err := parseInput(bytes.NewReader(input))
+		if err == nil && len(listA) != len(listB) {
+			t.Fatalf("lists of different lengths: %d and %d", len(listA), len(listB))
+		}
+	})
+}
@@ -0,0 +1,131 @@
+package day01
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"text/tabwriter"
+)
+
+// Matrix holds a metric for every pair of columns, where Matrix[i][j]
+// compares column i to column j.
+type Matrix [][]int
+
+// DistanceMatrix returns the distance between every pair of columns, which
+// must all be of the same length. Every column is sorted once up front rather
+// than for every pair it's part of.
+func DistanceMatrix(columns [][]int) Matrix {
+	sorted := make([][]int, len(columns))
+	for idx, column := range columns {
+		if len(column) != len(columns[0]) {
+			panic(fmt.Errorf("columns of different lengths: %d and %d", len(columns[0]), len(column)))
+		}
+
+		sorted[idx] = slices.Sorted(slices.Values(column))
+	}
+
+	return pairwise(len(columns), func(i, j int) int {
+		diff := 0
+		for idx := range sorted[i] {
+			diff += abs(sorted[i][idx] - sorted[j][idx])
+		}
+
+		return diff
+	})
+}
+
+// SimilarityMatrix returns the similarity score of every pair of columns.
+// Unlike distance, similarity isn't symmetric.
+func SimilarityMatrix(columns [][]int) Matrix {
+	freqMaps := make([]map[int]int, len(columns))
+	for idx, column := range columns {
+		freqMaps[idx] = make(map[int]int)
+		for _, num := range column {
+			freqMaps[idx][num]++
+		}
+	}
+
+	return pairwise(len(columns), func(i, j int) int {
+		score := 0
+		for _, num := range columns[i] {
+			score += num * freqMaps[j][num]
+		}
+
+		return score
+	})
+}
+
+func pairwise(n int, metric func(i, j int) int) Matrix {
+	m := make(Matrix, n)
+	for i := range m {
+		m[i] = make([]int, n)
+		for j := range m[i] {
+			m[i][j] = metric(i, j)
+		}
+	}
+
+	return m
+}
+
+// write writes the matrix as a table, numbering the columns from 1.
+func (m Matrix) write(w io.Writer, title string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	fmt.Fprintf(tw, "%s\t", title)
+	for j := range m {
+		fmt.Fprintf(tw, "%d\t", j+1)
+	}
+	fmt.Fprintln(tw)
+
+	for i, row := range m {
+		fmt.Fprintf(tw, "%d\t", i+1)
+		for _, v := range row {
+			fmt.Fprintf(tw, "%d\t", v)
+		}
+		fmt.Fprintln(tw)
+	}
+
+	return tw.Flush()
+}
+
+// matrix is the "matrix" command, comparing every pair of columns of an
+// input with any number of columns.
+func matrix(input io.Reader, out io.Writer, args []string) error {
+	var (
+		fs     = flag.NewFlagSet("matrix", flag.ContinueOnError)
+		format = fs.String("format", "table", "output format: table or json")
+	)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	columns, err := ParseColumns(input, 0)
+	if err != nil {
+		return err
+	}
+
+	var (
+		distance   = DistanceMatrix(columns)
+		similarity = SimilarityMatrix(columns)
+	)
+
+	switch *format {
+	case "table":
+		if err := distance.write(out, "distance"); err != nil {
+			return err
+		}
+
+		fmt.Fprintln(out)
+		return similarity.write(out, "similarity")
+
+	case "json":
+		return json.NewEncoder(out).Encode(struct {
+			Distance   Matrix `json:"distance"`
+			Similarity Matrix `json:"similarity"`
+		}{distance, similarity})
+	}
+
+	return fmt.Errorf("unknown format %q", *format)
+}
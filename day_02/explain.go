@@ -0,0 +1,96 @@
+package day02
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"aoc24/parse"
+)
+
+// explain is the "explain" command, telling for every report why it is or
+// isn't safe by a policy given through flags.
+func explain(input io.Reader, out io.Writer, args []string) error {
+	var (
+		fs     = flag.NewFlagSet("explain", flag.ContinueOnError)
+		policy = PolicyDampened
+		all    = fs.Bool("all", false, "include the reports that are safe as is")
+	)
+	fs.IntVar(&policy.MinDelta, "min", policy.MinDelta, "smallest difference allowed between adjacent levels")
+	fs.IntVar(&policy.MaxDelta, "max", policy.MaxDelta, "largest difference allowed between adjacent levels")
+	fs.BoolVar(&policy.Monotonic, "monotonic", policy.Monotonic, "require the levels to all increase or all decrease")
+	fs.IntVar(&policy.Tolerance, "tolerance", policy.Tolerance, "number of levels that may be removed")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if err := policy.Validate(); err != nil {
+		return fmt.Errorf("invalid policy: %w", err)
+	}
+
+	var (
+		r              = parse.NewReader(input)
+		reports, safes int
+	)
+
+	for line := range r.Lines() {
+		if len(line.Text) == 0 {
+			continue
+		}
+
+		report, err := parseReport(line.Number, line.Text)
+		if err != nil {
+			return err
+		}
+
+		reports++
+		e := policy.Explain(report)
+		if e.Safe {
+			safes++
+		}
+
+		if len(e.Violations) == 0 && !*all {
+			continue
+		}
+
+		fmt.Fprintf(out, "line %d: %s: %s\n", line.Number, line.Text, e.verdict())
+		for _, v := range e.Violations {
+			fmt.Fprintf(out, "  %v\n", v)
+		}
+	}
+
+	if err := r.Err(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "%d of %d reports are safe\n", safes, reports)
+	return nil
+}
+
+// verdict sums up the explanation in a few words, counting levels from 1.
+func (e Explanation) verdict() string {
+	switch {
+	case !e.Safe:
+		return "unsafe"
+
+	case len(e.Removals) == 0:
+		return "safe"
+	}
+
+	options := make([]string, 0, len(e.Removals))
+	for _, removed := range e.Removals {
+		levels := make([]string, 0, len(removed))
+		for _, idx := range removed {
+			levels = append(levels, fmt.Sprint(idx+1))
+		}
+
+		options = append(options, strings.Join(levels, " and "))
+	}
+
+	if len(e.Removals[0]) == 1 {
+		return "safe without level " + strings.Join(options, " or ")
+	}
+
+	return "safe without levels " + strings.Join(options, ", or ")
+}
@@ -0,0 +1,51 @@
+package day02
+
+import (
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+// generate produces size reports of 5 to 8 levels. Most reports start out
+// safe, after which some get a single bad level, which the dampener can
+// tolerate, or several.
+func generate(rng *rand.Rand, size int) []byte {
+	var b strings.Builder
+	for range size {
+		levels := generateReport(rng, 5+rng.IntN(4), rng.IntN(3))
+
+		for idx, level := range levels {
+			if idx > 0 {
+				b.WriteByte(' ')
+			}
+
+			b.WriteString(strconv.Itoa(level))
+		}
+
+		b.WriteByte('\n')
+	}
+
+	return []byte(b.String())
+}
+
+// generateReport produces a safe report of n levels, after which it replaces
+// bad of its levels by a random one.
+func generateReport(rng *rand.Rand, n, bad int) Report {
+	levels := make(Report, n)
+	levels[0] = 25 + rng.IntN(50)
+
+	direction := 1
+	if rng.IntN(2) == 0 {
+		direction = -1
+	}
+
+	for idx := 1; idx < len(levels); idx++ {
+		levels[idx] = levels[idx-1] + direction*(1+rng.IntN(3))
+	}
+
+	for range bad {
+		levels[rng.IntN(len(levels))] = 1 + rng.IntN(99)
+	}
+
+	return levels
+}
@@ -1,15 +1,18 @@
-package main
+package day02
 
 import (
-	"fmt"
 	"io"
-	"os"
 	"slices"
-	"strconv"
-	"strings"
+
+	"aoc24/aoc"
+	"aoc24/parse"
 )
 
 func deltas(input []int) []int {
+	if len(input) < 2 {
+		return nil
+	}
+
 	var (
 		deltas = make([]int, 0, len(input))
 		prev   = 0
@@ -26,132 +29,100 @@ func deltas(input []int) []int {
 
 type Report []int
 
-func allIncrease(deltas []int) bool {
-	for val := range slices.Values(deltas) {
-		if val <= 0 {
-			return false
-		}
+// IsSafe reports whether the report is safe by the rules of part one, or by
+// those of part two when the dampener is enabled.
+func (r Report) IsSafe(dampenerEnabled bool) bool {
+	if dampenerEnabled {
+		return PolicyDampened.Safe(r)
 	}
 
-	return true
+	return PolicyStrict.Safe(r)
 }
 
-func allDecrease(deltas []int) bool {
-	for val := range slices.Values(deltas) {
-		if val >= 0 {
-			return false
-		}
-	}
-	return true
-}
-
-func valid(r Report) bool {
-	d := deltas(r)
-	if !allIncrease(d) && !allDecrease(d) {
-		return false
+func abs(a int) int {
+	if a < 0 {
+		return a * -1
 	}
 
-	var (
-		maxDelta     = slices.Max(d)
-		minDelta     = slices.Min(d)
-		safeMaxDelta = abs(maxDelta) <= 3 && abs(maxDelta) >= 1
-		safeMinDelta = abs(minDelta) >= 1 && abs(minDelta) <= 3
-	)
-
-	return safeMaxDelta && safeMinDelta
+	return a
 }
 
-func (r Report) IsSafe(dampenerEnabled bool) bool {
-	if valid(r) {
-		return true
-	}
+func init() {
+	aoc.Register(2, Solver{})
+	aoc.RegisterGenerator(2, generate)
+	aoc.RegisterCommand(2, aoc.Command{
+		Name:    "explain",
+		Summary: "explain why every report is or isn't safe",
+		Run:     explain,
+	})
+}
 
-	if !dampenerEnabled {
-		return false
-	}
+type Solver struct{}
 
-	for p := range slices.Values(permutations(r)) {
-		if valid(p) {
-			return true
-		}
+func (Solver) PartOne(input io.Reader) (int, error) {
+	reports, err := parseInput(input)
+	if err != nil {
+		return 0, err
 	}
 
-	return false
+	return countSafe(reports, false), nil
 }
 
-func permutations(r Report) []Report {
-	permutated := make([]Report, 0, len(r))
-
-	for idx := range r {
-		permutated = append(permutated, without(r, idx))
+func (Solver) PartTwo(input io.Reader) (int, error) {
+	reports, err := parseInput(input)
+	if err != nil {
+		return 0, err
 	}
 
-	return permutated
-}
-
-func without(input Report, idx int) Report {
-	return slices.Concat(input[0:idx], input[idx+1:])
+	return countSafe(reports, true), nil
 }
 
-func abs(a int) int {
-	if a < 0 {
-		return a * -1
+func countSafe(reports []Report, dampenerEnabled bool) int {
+	safeReports := 0
+	for report := range slices.Values(reports) {
+		if report.IsSafe(dampenerEnabled) {
+			safeReports += 1
+		}
 	}
 
-	return a
+	return safeReports
 }
 
-func main() {
-	input, err := io.ReadAll(os.Stdin)
-	if err != nil {
-		panic(fmt.Errorf("error reading input: %w", err))
-	}
-
+func parseInput(input io.Reader) ([]Report, error) {
 	var (
-		lines   = strings.Split(string(input), "\n")
-		reports = make([]Report, 0, len(lines))
+		r       = parse.NewReader(input)
+		reports []Report
 	)
 
-	for line := range slices.Values(lines) {
-		if len(line) == 0 {
+	for line := range r.Lines() {
+		if len(line.Text) == 0 {
 			continue
 		}
-		report := parseReport(line)
-		reports = append(reports, report)
-	}
 
-	safeReports := 0
-	for report := range slices.Values(reports) {
-		if report.IsSafe(false) {
-			safeReports += 1
+		report, err := parseReport(line.Number, line.Text)
+		if err != nil {
+			return nil, err
 		}
-	}
 
-	fmt.Println("output part one: ", safeReports)
-
-	safeReports = 0
-	for report := range slices.Values(reports) {
-		if report.IsSafe(true) {
-			safeReports += 1
-		}
+		reports = append(reports, report)
 	}
 
-	fmt.Println("output part two: ", safeReports)
+	return reports, r.Err()
 }
 
-func parseReport(line string) Report {
+func parseReport(lineNo int, line string) (Report, error) {
 	var (
-		parts  = strings.Split(line, " ")
-		report = make(Report, 0, len(parts))
+		fields = parse.Fields(line)
+		report = make(Report, 0, len(fields))
 	)
 
-	for part := range slices.Values(parts) {
-		val, err := strconv.Atoi(part)
+	for _, field := range fields {
+		val, err := field.Int(lineNo)
 		if err != nil {
-			continue
+			return nil, err
 		}
 		report = append(report, val)
 	}
 
-	return report
+	return report, nil
 }
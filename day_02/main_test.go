@@ -0,0 +1,326 @@
+package day02
+
+import (
+	"bytes"
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"aoc24/aoc"
+	"aoc24/aoc/aoctest"
+	"aoc24/difftest"
+)
+
+func assert(t *testing.T, a, b any, msg string) {
+	t.Helper()
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("assertion failed: %s. Expected %+v, got %+v", msg, b, a)
+	}
+}
+
+func allIncrease(deltas []int) bool {
+	for val := range slices.Values(deltas) {
+		if val <= 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func allDecrease(deltas []int) bool {
+	for val := range slices.Values(deltas) {
+		if val >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// valid is the original check of part one.
+func valid(r Report) bool {
+	// a single level can't be unsafe
+	d := deltas(r)
+	if len(d) == 0 {
+		return true
+	}
+
+	if !allIncrease(d) && !allDecrease(d) {
+		return false
+	}
+
+	var (
+		maxDelta     = slices.Max(d)
+		minDelta     = slices.Min(d)
+		safeMaxDelta = abs(maxDelta) <= 3 && abs(maxDelta) >= 1
+		safeMinDelta = abs(minDelta) >= 1 && abs(minDelta) <= 3
+	)
+
+	return safeMaxDelta && safeMinDelta
+}
+
+// dampenedPermutations is the original dampener, checking the report with
+// each of its levels removed in turn.
+func dampenedPermutations(r Report) bool {
+	if valid(r) {
+		return true
+	}
+
+	for p := range slices.Values(permutations(r)) {
+		if valid(p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func permutations(r Report) []Report {
+	permutated := make([]Report, 0, len(r))
+
+	for idx := range r {
+		permutated = append(permutated, without(r, idx))
+	}
+
+	return permutated
+}
+
+func without(input Report, idx int) Report {
+	return slices.Concat(input[0:idx], input[idx+1:])
+}
+
+func TestReport_IsSafe(t *testing.T) {
+	tests := []struct {
+		report   Report
+		safe     bool
+		dampened bool
+	}{
+		{Report{7, 6, 4, 2, 1}, true, true},
+		{Report{1, 2, 7, 8, 9}, false, false},
+		{Report{9, 7, 6, 2, 1}, false, false},
+		{Report{1, 3, 2, 4, 5}, false, true},
+		{Report{8, 6, 4, 4, 1}, false, true},
+		{Report{1, 3, 6, 7, 9}, true, true},
+
+		// the first or last level is the odd one out
+		{Report{5, 1, 2, 3, 4}, false, true},
+		{Report{1, 2, 3, 4, 9}, false, true},
+		{Report{3, 1, 2, 3}, false, true},
+		{Report{4}, true, true},
+		{Report{}, true, true},
+	}
+
+	for _, test := range tests {
+		assert(t, test.report.IsSafe(false), test.safe, fmt.Sprintf("safety of %v", test.report))
+		assert(t, test.report.IsSafe(true), test.dampened, fmt.Sprintf("safety of %v with the dampener", test.report))
+	}
+}
+
+func TestDifferentialDampener(t *testing.T) {
+	difftest.Case[[]int, bool]{
+		Generate: func(rng *rand.Rand) []int {
+			return generateReport(rng, 1+rng.IntN(10), rng.IntN(4))
+		},
+		Shrink: func(levels []int) iter.Seq[[]int] {
+			return difftest.Concat(
+				difftest.ShrinkSlice(levels),
+				difftest.ShrinkElements(levels, difftest.ShrinkInt),
+			)
+		},
+		Naive: func(levels []int) bool {
+			return dampenedPermutations(levels)
+		},
+		Optimized: func(levels []int) bool {
+			return PolicyDampened.Safe(levels)
+		},
+	}.Run(t, 2000)
+}
+
+// safeByRemoval checks the report against the policy with every combination
+// of at most k of its levels removed.
+func safeByRemoval(p SafetyPolicy, r Report, k int) bool {
+	strict := p
+	strict.Tolerance = 0
+	if strict.Safe(r) {
+		return true
+	}
+
+	if k == 0 {
+		return false
+	}
+
+	for idx := range r {
+		if safeByRemoval(p, without(r, idx), k-1) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestDifferentialTolerance(t *testing.T) {
+	policies := []SafetyPolicy{
+		{MinDelta: 1, MaxDelta: 3, Monotonic: true, Tolerance: 2},
+		{MinDelta: 1, MaxDelta: 3, Monotonic: true, Tolerance: 3},
+		{MinDelta: 0, MaxDelta: 2, Monotonic: false, Tolerance: 2},
+	}
+
+	for _, p := range policies {
+		t.Run(fmt.Sprintf("%+v", p), func(t *testing.T) {
+			difftest.Case[[]int, bool]{
+				Generate: func(rng *rand.Rand) []int {
+					return generateReport(rng, 1+rng.IntN(10), rng.IntN(5))
+				},
+				Shrink: func(levels []int) iter.Seq[[]int] {
+					return difftest.Concat(
+						difftest.ShrinkSlice(levels),
+						difftest.ShrinkElements(levels, difftest.ShrinkInt),
+					)
+				},
+				Naive: func(levels []int) bool {
+					return safeByRemoval(p, levels, p.Tolerance)
+				},
+				Optimized: func(levels []int) bool {
+					return p.Safe(levels)
+				},
+			}.Run(t, 1000)
+		})
+	}
+}
+
+func TestSafetyPolicy_Explain(t *testing.T) {
+	tests := []struct {
+		policy SafetyPolicy
+		report Report
+		want   Explanation
+	}{
+		{
+			PolicyDampened,
+			Report{7, 6, 4, 2, 1},
+			Explanation{Safe: true},
+		},
+		{
+			PolicyDampened,
+			Report{1, 3, 2, 4, 5},
+			Explanation{
+				Safe:       true,
+				Violations: []Violation{{Index: 2, Delta: -1, Rule: RuleDirection}},
+				Removals:   [][]int{{1}, {2}},
+			},
+		},
+		{
+			PolicyDampened,
+			Report{1, 2, 7, 8, 9},
+			Explanation{
+				Violations: []Violation{{Index: 2, Delta: 5, Rule: RuleDelta}},
+			},
+		},
+		{
+			PolicyStrict,
+			Report{8, 6, 4, 4, 1},
+			Explanation{
+				Violations: []Violation{{Index: 3, Delta: 0, Rule: RuleDelta}},
+			},
+		},
+		{
+			SafetyPolicy{MinDelta: 1, MaxDelta: 3, Monotonic: true, Tolerance: 2},
+			Report{1, 9, 2, 3, 1, 4},
+			Explanation{
+				Safe: true,
+				Violations: []Violation{
+					{Index: 1, Delta: 8, Rule: RuleDelta},
+					{Index: 2, Delta: -7, Rule: RuleDirection},
+					{Index: 2, Delta: -7, Rule: RuleDelta},
+					{Index: 4, Delta: -2, Rule: RuleDirection},
+				},
+				Removals: [][]int{{1, 4}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		assert(t, test.policy.Explain(test.report), test.want, fmt.Sprintf("explanation of %v", test.report))
+		assert(t, test.policy.Safe(test.report), test.want.Safe, fmt.Sprintf("safety of %v", test.report))
+	}
+}
+
+func TestExplainCommand(t *testing.T) {
+	c, found := aoc.LookupCommand(2, "explain")
+	assert(t, found, true, "explain command should be registered")
+
+	var out bytes.Buffer
+	err := c.Run(strings.NewReader("7 6 4 2 1\n1 2 7 8 9\n1 3 2 4 5\n"), &out, []string{"-tolerance", "1"})
+	assert(t, err, nil, "unexpected error")
+	assert(t, out.String(), `line 2: 1 2 7 8 9: unsafe
+  level 3 changes by 5, breaking the delta rule
+line 3: 1 3 2 4 5: safe without level 2 or 3
+  level 3 changes by -1, breaking the direction rule
+2 of 3 reports are safe
+`, "incorrect explanation")
+
+	for _, args := range [][]string{{"-tolerance", "-1"}, {"-min", "4", "-max", "3"}, {"-min", "-1"}} {
+		err = c.Run(strings.NewReader("7 6 4 2 1\n"), &out, args)
+		assert(t, err != nil, true, fmt.Sprintf("invalid policy %v should be rejected", args))
+	}
+}
+
+func TestSafetyPolicy_Validate(t *testing.T) {
+	assert(t, PolicyStrict.Validate(), nil, "strict policy should be valid")
+	assert(t, PolicyDampened.Validate(), nil, "dampened policy should be valid")
+	assert(t, SafetyPolicy{MinDelta: 1, MaxDelta: 3, Tolerance: -1}.Validate() != nil, true, "negative tolerance should be invalid")
+	assert(t, SafetyPolicy{MinDelta: 3, MaxDelta: 1}.Validate() != nil, true, "reversed deltas should be invalid")
+	assert(t, SafetyPolicy{MinDelta: 0, MaxDelta: 3, Monotonic: true}.Validate() != nil, true, "monotonic levels should change")
+	assert(t, SafetyPolicy{MinDelta: -1, MaxDelta: 3, Monotonic: true}.Validate() != nil, true, "negative delta of monotonic levels should be invalid")
+	assert(t, SafetyPolicy{MinDelta: -1, MaxDelta: 3}.Validate() != nil, true, "negative delta should be invalid")
+	assert(t, SafetyPolicy{MinDelta: 0, MaxDelta: 3}.Validate(), nil, "levels that aren't monotonic may stay the same")
+
+	// a negative tolerance tolerates nothing rather than everything
+	report := make(Report, 26)
+	for idx := range report {
+		report[idx] = idx % 2 * 10
+	}
+
+	p := SafetyPolicy{MinDelta: 1, MaxDelta: 3, Monotonic: true, Tolerance: -1}
+	assert(t, p.Safe(report), false, "alternating report shouldn't be safe")
+	assert(t, p.Explain(report).Safe, false, "alternating report shouldn't be explained safe")
+}
+
+func BenchmarkDampener(b *testing.B) {
+	rng := aoc.NewRand(1)
+
+	for _, levels := range []int{10, 100, 1000} {
+		// a single bad level near the end, so neither can bail out early
+		report := generateReport(rng, levels, 0)
+		report[levels-2] = report[levels-1]
+
+		b.Run(fmt.Sprintf("permutations/levels=%d", levels), func(b *testing.B) {
+			for range b.N {
+				dampenedPermutations(report)
+			}
+		})
+
+		b.Run(fmt.Sprintf("linear/levels=%d", levels), func(b *testing.B) {
+			for range b.N {
+				PolicyDampened.Safe(report)
+			}
+		})
+	}
+}
+
+func FuzzParseInput(f *testing.F) {
+	aoctest.FuzzSeeds(f, generate)
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		reports, err := parseInput(bytes.NewReader(input))
+		if err != nil {
+			return
+		}
+
+		// checking the reports is cheap, and shouldn't trip over odd reports
+		countSafe(reports, true)
+	})
+}
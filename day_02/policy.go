@@ -0,0 +1,272 @@
+package day02
+
+import (
+	"fmt"
+	"slices"
+)
+
+// SafetyPolicy holds the rules a report has to follow to be safe.
+type SafetyPolicy struct {
+	// MinDelta and MaxDelta bound the difference between adjacent levels,
+	// regardless of its sign.
+	MinDelta, MaxDelta int
+
+	// Monotonic requires the levels to either all increase or all decrease.
+	Monotonic bool
+
+	// Tolerance is the number of levels that may be removed to make a
+	// report safe.
+	Tolerance int
+}
+
+var (
+	// PolicyStrict are the rules of part one.
+	PolicyStrict = SafetyPolicy{MinDelta: 1, MaxDelta: 3, Monotonic: true}
+
+	// PolicyDampened are the rules of part two, where the Problem Dampener
+	// tolerates a single bad level.
+	PolicyDampened = SafetyPolicy{MinDelta: 1, MaxDelta: 3, Monotonic: true, Tolerance: 1}
+)
+
+// Validate reports an error when the policy can't be applied, because its
+// tolerance is negative or its delta bounds are reversed or out of range.
+// Monotonic levels have to change by at least 1, otherwise a level may not
+// change by less than nothing.
+func (p SafetyPolicy) Validate() error {
+	if p.Tolerance < 0 {
+		return fmt.Errorf("tolerance %d is negative", p.Tolerance)
+	}
+
+	if p.Monotonic && p.MinDelta < 1 {
+		return fmt.Errorf("smallest delta %d of monotonic levels is less than 1", p.MinDelta)
+	}
+
+	if p.MinDelta < 0 {
+		return fmt.Errorf("smallest delta %d is negative", p.MinDelta)
+	}
+
+	if p.MinDelta > p.MaxDelta {
+		return fmt.Errorf("smallest delta %d exceeds largest delta %d", p.MinDelta, p.MaxDelta)
+	}
+
+	return nil
+}
+
+// Rule is one of the rules of a policy.
+type Rule string
+
+const (
+	RuleDelta     Rule = "delta"
+	RuleDirection Rule = "direction"
+)
+
+// Violation is a step between two adjacent levels that breaks a rule.
+type Violation struct {
+	// Index is the index of the level the step leads to.
+	Index int
+	Delta int
+	Rule  Rule
+}
+
+// String describes the violation, counting levels from 1.
+func (v Violation) String() string {
+	return fmt.Sprintf("level %d changes by %d, breaking the %s rule", v.Index+1, v.Delta, v.Rule)
+}
+
+// Explanation tells why a report is or isn't safe.
+type Explanation struct {
+	Safe bool
+
+	// Violations are the steps of the report as is that break a rule.
+	Violations []Violation
+
+	// Removals are the smallest sets of levels, by index, whose removal
+	// makes the report safe within the tolerance of the policy. It's empty
+	// when the report is safe as is, or can't be made safe.
+	Removals [][]int
+}
+
+// directions returns the directions a report may head in, where 0 means
+// any direction goes.
+func (p SafetyPolicy) directions() []int {
+	if p.Monotonic {
+		return []int{1, -1}
+	}
+
+	return []int{0}
+}
+
+// safeStep reports whether a step from level a to level b follows the rules
+// when heading in direction.
+func (p SafetyPolicy) safeStep(a, b, direction int) bool {
+	delta := b - a
+	if direction != 0 {
+		delta *= direction
+	} else {
+		delta = abs(delta)
+	}
+
+	return delta >= p.MinDelta && delta <= p.MaxDelta
+}
+
+// firstUnsafe returns the index of the first level that's followed by an
+// unsafe step in the given direction, leaving out the removed levels, or -1
+// when every step is safe.
+func (p SafetyPolicy) firstUnsafe(r Report, removed []int, direction int) int {
+	prev := -1
+	for idx := range r {
+		if slices.Contains(removed, idx) {
+			continue
+		}
+
+		if prev >= 0 && !p.safeStep(r[prev], r[idx], direction) {
+			return prev
+		}
+
+		prev = idx
+	}
+
+	return -1
+}
+
+// next returns the index of the first level after idx that isn't removed.
+func next(r Report, removed []int, idx int) int {
+	idx++
+	for idx < len(r) && slices.Contains(removed, idx) {
+		idx++
+	}
+
+	return idx
+}
+
+// removals calls yield with sets of at most tolerance levels whose removal on
+// top of the removed levels makes the report safe in the given direction.
+// Removing a level other than the two making up the first unsafe step leaves
+// that step in place, so only those two are tried, which keeps the search
+// linear in the length of the report for a fixed tolerance. Every smallest
+// set is found, along with some that aren't the smallest.
+func (p SafetyPolicy) removals(r Report, removed []int, tolerance, direction int, yield func([]int) bool) bool {
+	bad := p.firstUnsafe(r, removed, direction)
+	if bad < 0 {
+		return yield(removed)
+	}
+
+	if tolerance <= 0 {
+		return true
+	}
+
+	for _, idx := range []int{bad, next(r, removed, bad)} {
+		if !p.removals(r, append(slices.Clip(removed), idx), tolerance-1, direction, yield) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Safe reports whether the report follows the rules, with at most Tolerance
+// of its levels removed.
+func (p SafetyPolicy) Safe(r Report) bool {
+	for _, direction := range p.directions() {
+		safe := false
+		p.removals(r, nil, p.Tolerance, direction, func([]int) bool {
+			safe = true
+			return false
+		})
+
+		if safe {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Explain tells which steps of the report break which rules and which levels
+// can be removed to make it safe.
+func (p SafetyPolicy) Explain(r Report) Explanation {
+	e := Explanation{Violations: p.violations(r)}
+	if len(e.Violations) == 0 {
+		e.Safe = true
+		return e
+	}
+
+	var found [][]int
+	for _, direction := range p.directions() {
+		p.removals(r, nil, p.Tolerance, direction, func(removed []int) bool {
+			found = append(found, slices.Sorted(slices.Values(removed)))
+			return true
+		})
+	}
+
+	if len(found) == 0 {
+		return e
+	}
+
+	// keep the distinct sets of the smallest size
+	size := len(slices.MinFunc(found, func(a, b []int) int { return len(a) - len(b) }))
+	found = slices.DeleteFunc(found, func(removed []int) bool { return len(removed) != size })
+	slices.SortFunc(found, slices.Compare)
+
+	e.Safe = true
+	e.Removals = slices.CompactFunc(found, slices.Equal)
+	return e
+}
+
+// violations returns the steps of the report that break a rule. Steps that
+// don't head in the direction most steps head in break the direction rule.
+func (p SafetyPolicy) violations(r Report) []Violation {
+	var (
+		res       []Violation
+		direction int
+		d         = deltas(r)
+	)
+
+	if p.Monotonic {
+		direction = majority(d)
+	}
+
+	for idx, delta := range d {
+		if direction != 0 && delta*direction < 0 {
+			res = append(res, Violation{Index: idx + 1, Delta: delta, Rule: RuleDirection})
+		}
+
+		if !p.safeStep(0, delta, 0) {
+			res = append(res, Violation{Index: idx + 1, Delta: delta, Rule: RuleDelta})
+		}
+	}
+
+	return res
+}
+
+// majority returns the direction most deltas head in, the direction of the
+// first delta that isn't zero on a tie, or 0 when all deltas are zero.
+func majority(deltas []int) int {
+	var balance, first int
+	for _, delta := range deltas {
+		sign := 0
+		switch {
+		case delta > 0:
+			sign = 1
+
+		case delta < 0:
+			sign = -1
+		}
+
+		if first == 0 {
+			first = sign
+		}
+
+		balance += sign
+	}
+
+	switch {
+	case balance > 0:
+		return 1
+
+	case balance < 0:
+		return -1
+	}
+
+	return first
+}
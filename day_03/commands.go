@@ -0,0 +1,81 @@
+package day03
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// trace is the "trace" command, executing the instructions under the given
+// semantics while printing every step.
+func trace(input io.Reader, out io.Writer, args []string) error {
+	var (
+		fs   = flag.NewFlagSet("trace", flag.ContinueOnError)
+		name = fs.String("semantics", "conditional", "semantics to execute the instructions under: "+strings.Join(SemanticsNames(), " or "))
+	)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	s, found := LookupSemantics(*name)
+	if !found {
+		return fmt.Errorf("unknown semantics %q", *name)
+	}
+
+	instructions, err := parseInput(input)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "%8s  %-14s  %-8s  %-8s  %s\n", "offset", "instruction", "machine", "result", "acc")
+	Interpreter{Semantics: s, Trace: out}.Run(instructions)
+	return nil
+}
+
+// disassemble is the "disassemble" command, listing the valid instructions
+// found in the corrupted memory along with their position.
+func disassemble(input io.Reader, out io.Writer, args []string) error {
+	var (
+		fs          = flag.NewFlagSet("disassemble", flag.ContinueOnError)
+		diagnostics = fs.Bool("diagnostics", false, "list the rejected near-matches as well")
+	)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	d := NewDecoder(input, DefaultGrammar)
+
+	// instructions and near-matches never start at the same position, so
+	// both can be merged by position
+	type line struct {
+		pos  int
+		text string
+	}
+
+	var listing []line
+	for ins := range d.Instructions() {
+		listing = append(listing, line{ins.Position, ins.String()})
+	}
+
+	if err := d.Err(); err != nil {
+		return err
+	}
+
+	if *diagnostics {
+		for _, diag := range d.Diagnostics() {
+			listing = append(listing, line{diag.Position, fmt.Sprintf("; rejected %q: %s", diag.Text, diag.Reason)})
+		}
+
+		slices.SortStableFunc(listing, func(a, b line) int { return a.pos - b.pos })
+	}
+
+	for _, l := range listing {
+		if _, err := fmt.Fprintf(out, "%8d  %s\n", l.pos, l.text); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
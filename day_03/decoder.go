@@ -0,0 +1,126 @@
+package day03
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// Diagnostic describes a near-match: text starting with the name of an
+// instruction and its opening parenthesis, which doesn't follow the
+// definition of the instruction any further.
+type Diagnostic struct {
+	// Position is the byte offset of the near-match within the input.
+	Position int
+
+	// Text is the near-match up to and including the byte that rejected it,
+	// if there was one before the end of the input.
+	Text   string
+	Reason string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("offset %d: %q: %s", d.Position, d.Text, d.Reason)
+}
+
+// Decoder decodes the instructions of a grammar from corrupted memory,
+// reading no more of it than the longest instruction at a time.
+type Decoder struct {
+	r           *bufio.Reader
+	g           *Grammar
+	pos         int
+	err         error
+	diagnostics []Diagnostic
+}
+
+// NewDecoder returns a decoder for the instructions of g read from r.
+func NewDecoder(r io.Reader, g *Grammar) *Decoder {
+	return &Decoder{
+		r: bufio.NewReaderSize(r, max(4096, g.maxLen)),
+		g: g,
+	}
+}
+
+// Instructions iterates over the remaining instructions in the input, with
+// their position relative to the start of the input. Iteration stops at the
+// end of the input or at the first read error, which is returned by Err
+// afterwards. Instructions read in full before the error are still decoded.
+func (d *Decoder) Instructions() iter.Seq[Instruction] {
+	return func(yield func(Instruction) bool) {
+		for {
+			ins, ok := d.next()
+			if !ok || !yield(ins) {
+				return
+			}
+		}
+	}
+}
+
+// Diagnostics returns the near-matches found so far.
+func (d *Decoder) Diagnostics() []Diagnostic {
+	return d.diagnostics
+}
+
+// Err returns the first error, other than io.EOF, encountered while reading.
+func (d *Decoder) Err() error {
+	return d.err
+}
+
+// next decodes the next instruction, skipping everything in front of it.
+func (d *Decoder) next() (Instruction, bool) {
+	for {
+		buf := d.peek()
+		if len(buf) == 0 {
+			return Instruction{}, false
+		}
+
+		// most of the memory is garbage, which doesn't even start out like
+		// an instruction
+		if !d.g.starts[buf[0]] {
+			d.skip(1)
+			continue
+		}
+
+		ins, n, reason := d.g.match(string(buf))
+		if reason != "" {
+			d.diagnostics = append(d.diagnostics, Diagnostic{
+				Position: d.pos,
+				Text:     string(buf[:min(n+1, len(buf))]),
+				Reason:   reason,
+			})
+		}
+
+		if n == 0 || reason != "" {
+			d.skip(1)
+			continue
+		}
+
+		ins.Position = d.pos
+		d.skip(n)
+		return ins, true
+	}
+}
+
+// peek returns the next bytes of the input, as many as the longest
+// instruction, unless the input ends first. The reader only returns an error
+// once, so after the first one the bytes buffered so far are all that's left.
+func (d *Decoder) peek() []byte {
+	if d.err != nil {
+		buf, _ := d.r.Peek(min(d.g.maxLen, d.r.Buffered()))
+		return buf
+	}
+
+	buf, err := d.r.Peek(d.g.maxLen)
+	if err != nil && !errors.Is(err, io.EOF) {
+		d.err = err
+	}
+
+	return buf
+}
+
+func (d *Decoder) skip(n int) {
+	d.r.Discard(n)
+	d.pos += n
+}
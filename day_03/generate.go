@@ -0,0 +1,49 @@
+package day03
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+// near misses of valid instructions, mixed in with the garbage
+var corruptions = []string{
+	"mul[3,7]",
+	"mul(32,64]",
+	"mul ( 2 , 4 )",
+	"mul(4*",
+	"mul(6,9!",
+	"?(12,34)",
+	"do_not_",
+	"undo",
+	"don't",
+}
+
+// generate produces corrupted memory of roughly size bytes, holding valid mul,
+// do and don't instructions between garbage and near misses.
+func generate(rng *rand.Rand, size int) []byte {
+	const garbage = "!@#$%^&*()[]{}<>?/+-_=,.;:'\" xyzwhen"
+
+	var b strings.Builder
+	for b.Len() < size {
+		switch n := rng.IntN(20); {
+		case n < 4:
+			fmt.Fprintf(&b, "mul(%d,%d)", rng.IntN(1000), rng.IntN(1000))
+
+		case n < 5:
+			b.WriteString("do()")
+
+		case n < 6:
+			b.WriteString("don't()")
+
+		case n < 8:
+			b.WriteString(corruptions[rng.IntN(len(corruptions))])
+
+		default:
+			b.WriteByte(garbage[rng.IntN(len(garbage))])
+		}
+	}
+
+	b.WriteByte('\n')
+	return []byte(b.String())
+}
@@ -0,0 +1,172 @@
+package day03
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Definition describes an instruction hidden in the corrupted memory: its
+// name, directly followed by its arguments between parentheses, separated by
+// commas and without any whitespace, like mul(2,4) or do().
+type Definition struct {
+	Name InstructionType
+
+	// Args is the number of arguments the instruction takes.
+	Args int
+
+	// MaxDigits is the largest number of digits an argument may have, at
+	// most maxDigits.
+	MaxDigits int
+}
+
+// maxDigits is the largest number of digits of an argument that always fits
+// in an int.
+const maxDigits = 18
+
+// maxLen returns the length of the longest valid instruction.
+func (d Definition) maxLen() int {
+	n := len(d.Name) + 2
+	if d.Args > 0 {
+		n += d.Args*d.MaxDigits + d.Args - 1
+	}
+
+	return n
+}
+
+// match matches the instruction against the start of s, returning its
+// arguments and length. When s starts with the name of the instruction and
+// its opening parenthesis but the rest doesn't match, reason tells why and n
+// is the offset of the byte it was rejected at.
+func (d Definition) match(s string) (args []int, n int, reason string) {
+	open := string(d.Name) + "("
+	if !strings.HasPrefix(s, open) {
+		return nil, 0, ""
+	}
+
+	n = len(open)
+	for idx := range d.Args {
+		if idx > 0 {
+			if n >= len(s) || s[n] != ',' {
+				return nil, n, "expected ','"
+			}
+			n++
+		}
+
+		var arg, digits int
+		for ; n < len(s) && s[n] >= '0' && s[n] <= '9'; n++ {
+			arg = arg*10 + int(s[n]-'0')
+			digits++
+
+			if digits > d.MaxDigits {
+				return nil, n, fmt.Sprintf("argument has more than %d digits", d.MaxDigits)
+			}
+		}
+
+		if digits == 0 {
+			return nil, n, "expected a digit"
+		}
+
+		args = append(args, arg)
+	}
+
+	if n >= len(s) || s[n] != ')' {
+		return nil, n, "expected ')'"
+	}
+
+	return args, n + 1, ""
+}
+
+// Grammar holds the definitions of the instructions a tokenizer recognizes.
+type Grammar struct {
+	defs   map[InstructionType]Definition
+	maxLen int
+
+	// starts holds the first byte of every name
+	starts [256]bool
+}
+
+// NewGrammar returns a grammar recognizing the given instructions.
+func NewGrammar(defs ...Definition) *Grammar {
+	g := &Grammar{defs: map[InstructionType]Definition{}}
+	for _, d := range defs {
+		g.Register(d)
+	}
+
+	return g
+}
+
+// DefaultGrammar recognizes the instructions of the puzzle.
+var DefaultGrammar = NewGrammar(
+	Definition{Name: InstructionTypeMul, Args: 2, MaxDigits: 3},
+	Definition{Name: InstructionTypeDo},
+	Definition{Name: InstructionTypeDont},
+)
+
+// Register adds the definition of an instruction to the grammar. It panics
+// when an instruction by the same name was registered before, or when its
+// arguments may have more digits than fit in an int.
+func (g *Grammar) Register(d Definition) {
+	if _, exists := g.defs[d.Name]; exists {
+		panic(fmt.Errorf("instruction %q already registered", d.Name))
+	}
+
+	if d.Name == "" || strings.ContainsAny(string(d.Name), "(),") {
+		panic(fmt.Errorf("invalid instruction name %q", d.Name))
+	}
+
+	if d.Args > 0 && d.MaxDigits < 1 {
+		panic(fmt.Errorf("instruction %q takes arguments of no digits", d.Name))
+	}
+
+	if d.MaxDigits > maxDigits {
+		panic(fmt.Errorf("instruction %q takes arguments of more than %d digits", d.Name, maxDigits))
+	}
+
+	g.defs[d.Name] = d
+	g.maxLen = max(g.maxLen, d.maxLen())
+	g.starts[d.Name[0]] = true
+}
+
+// Lookup returns the definition of the instruction by name.
+func (g *Grammar) Lookup(name InstructionType) (Definition, bool) {
+	d, found := g.defs[name]
+	return d, found
+}
+
+// Definitions returns the definitions of the grammar ordered by name.
+func (g *Grammar) Definitions() []Definition {
+	var res []Definition
+	for _, name := range slices.Sorted(maps.Keys(g.defs)) {
+		res = append(res, g.defs[name])
+	}
+
+	return res
+}
+
+// match matches any of the instructions against the start of s. Names never
+// hold parentheses, so at most one instruction has its name and opening
+// parenthesis at the start of s.
+func (g *Grammar) match(s string) (ins Instruction, n int, reason string) {
+	for _, d := range g.defs {
+		args, n, reason := d.match(s)
+		if n == 0 {
+			continue
+		}
+
+		if reason != "" {
+			return Instruction{}, n, reason
+		}
+
+		return Instruction{Typ: d.Name, Args: args}, n, ""
+	}
+
+	return Instruction{}, 0, ""
+}
+
+// Tokenize finds all instructions of the grammar in the corrupted memory,
+// skipping everything else. Positions are byte offsets within input.
+func (g *Grammar) Tokenize(input string) []Instruction {
+	return slices.Collect(NewDecoder(strings.NewReader(input), g).Instructions())
+}
@@ -0,0 +1,116 @@
+package day03
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+)
+
+// State is the state of the machine executing the instructions.
+type State struct {
+	// Acc accumulates the results of the instructions.
+	Acc int
+
+	// Enabled tells whether instructions that can be disabled are executed.
+	Enabled bool
+}
+
+// Semantics executes a single instruction, updating the state of the machine.
+// It reports whether the instruction was executed.
+type Semantics func(s *State, ins Instruction) bool
+
+// Unconditional executes every mul instruction, ignoring do and don't, like
+// part one does. A mul that doesn't take two arguments, as defined by a
+// custom grammar, isn't executed.
+func Unconditional(s *State, ins Instruction) bool {
+	if ins.Typ != InstructionTypeMul || len(ins.Args) != 2 {
+		return false
+	}
+
+	s.Acc += ins.Args[0] * ins.Args[1]
+	return true
+}
+
+// Conditional executes mul instructions unless a don't instruction disabled
+// them, until a do instruction enables them again, like part two does.
+func Conditional(s *State, ins Instruction) bool {
+	switch ins.Typ {
+	case InstructionTypeDo:
+		s.Enabled = true
+		return true
+
+	case InstructionTypeDont:
+		s.Enabled = false
+		return true
+
+	case InstructionTypeMul:
+		if !s.Enabled {
+			return false
+		}
+
+		return Unconditional(s, ins)
+	}
+
+	return false
+}
+
+var semantics = map[string]Semantics{
+	"unconditional": Unconditional,
+	"conditional":   Conditional,
+}
+
+// RegisterSemantics makes the semantics available by name. It panics when
+// semantics by the same name were registered before.
+func RegisterSemantics(name string, s Semantics) {
+	if _, exists := semantics[name]; exists {
+		panic(fmt.Errorf("semantics %q already registered", name))
+	}
+
+	semantics[name] = s
+}
+
+// LookupSemantics returns the semantics registered by name.
+func LookupSemantics(name string) (Semantics, bool) {
+	s, found := semantics[name]
+	return s, found
+}
+
+// SemanticsNames returns the names of all registered semantics in order.
+func SemanticsNames() []string {
+	return slices.Sorted(maps.Keys(semantics))
+}
+
+// Interpreter executes instructions under the given semantics.
+type Interpreter struct {
+	Semantics Semantics
+
+	// Trace, when set, receives a line per instruction holding its position,
+	// whether the machine was enabled, whether the instruction was executed
+	// and the accumulator after executing it.
+	Trace io.Writer
+}
+
+// Run executes the instructions, starting out enabled, and returns the
+// accumulator.
+func (in Interpreter) Run(instructions []Instruction) int {
+	s := State{Enabled: true}
+	for _, ins := range instructions {
+		enabled := s.Enabled
+		executed := in.Semantics(&s, ins)
+
+		if in.Trace != nil {
+			fmt.Fprintf(in.Trace, "%8d  %-14s  %-8s  %-8s  %d\n", ins.Position, ins, onOff(enabled, "enabled", "disabled"), onOff(executed, "executed", "skipped"), s.Acc)
+		}
+	}
+
+	return s.Acc
+}
+
+func onOff(b bool, on, off string) string {
+	if b {
+		return on
+	}
+
+	return off
+}
@@ -0,0 +1,193 @@
+package day03
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"aoc24/aoc"
+	"aoc24/aoc/aoctest"
+)
+
+func assert(t *testing.T, a, b any, msg string) {
+	t.Helper()
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("assertion failed: %s. Expected %+v, got %+v", msg, b, a)
+	}
+}
+
+func TestGrammar_Tokenize(t *testing.T) {
+	instructions := DefaultGrammar.Tokenize("xmul(2,4)&mul[3,7]!^don't()_mul(5,5)+mul(32,64](mul(11,8)undo()?mul(8,5))")
+	assert(t, instructions, []Instruction{
+		{Position: 1, Typ: InstructionTypeMul, Args: []int{2, 4}},
+		{Position: 20, Typ: InstructionTypeDont},
+		{Position: 28, Typ: InstructionTypeMul, Args: []int{5, 5}},
+		{Position: 48, Typ: InstructionTypeMul, Args: []int{11, 8}},
+		{Position: 59, Typ: InstructionTypeDo},
+		{Position: 64, Typ: InstructionTypeMul, Args: []int{8, 5}},
+	}, "incorrect instructions")
+
+	// arguments are limited to three digits, without signs or whitespace
+	instructions = DefaultGrammar.Tokenize("mul(1234,5)mul(+1,2)mul( 1,2)mul(1,2 )mul(123,456)")
+	assert(t, instructions, []Instruction{
+		{Position: 38, Typ: InstructionTypeMul, Args: []int{123, 456}},
+	}, "only the last instruction is valid")
+}
+
+func TestDecoder(t *testing.T) {
+	// an instruction straddling the buffer of the decoder, on the second line
+	input := strings.Repeat("x", 4094) + "\nmul(2,4)do()\nmul(4*mul(1234,5)mul(6,9"
+
+	for _, r := range []io.Reader{
+		strings.NewReader(input),
+		iotest.OneByteReader(strings.NewReader(input)),
+		iotest.HalfReader(strings.NewReader(input)),
+	} {
+		d := NewDecoder(r, DefaultGrammar)
+		assert(t, slices.Collect(d.Instructions()), []Instruction{
+			{Position: 4095, Typ: InstructionTypeMul, Args: []int{2, 4}},
+			{Position: 4103, Typ: InstructionTypeDo},
+		}, "incorrect instructions")
+		assert(t, d.Err(), nil, "unexpected error")
+		assert(t, d.Diagnostics(), []Diagnostic{
+			{Position: 4108, Text: "mul(4*", Reason: "expected ','"},
+			{Position: 4114, Text: "mul(1234", Reason: "argument has more than 3 digits"},
+			{Position: 4125, Text: "mul(6,9", Reason: "expected ')'"},
+		}, "incorrect diagnostics")
+	}
+}
+
+func TestDecoder_Err(t *testing.T) {
+	errRead := errors.New("read failed")
+	d := NewDecoder(io.MultiReader(strings.NewReader("mul(2,4)"), iotest.ErrReader(errRead)), DefaultGrammar)
+
+	assert(t, len(slices.Collect(d.Instructions())), 1, "instructions in front of the error should be decoded")
+	assert(t, d.Err(), errRead, "read error should be returned")
+
+	// a reader that recovers after failing shouldn't be read any further
+	d = NewDecoder(io.MultiReader(strings.NewReader("mul(2,4)"), &failOnce{err: errRead, r: strings.NewReader("mul(3,3)")}), DefaultGrammar)
+	assert(t, slices.Collect(d.Instructions()), []Instruction{
+		{Position: 0, Typ: InstructionTypeMul, Args: []int{2, 4}},
+	}, "instructions after the error shouldn't be decoded")
+	assert(t, d.Err(), errRead, "read error should be kept")
+}
+
+// failOnce fails the first read and reads from r afterwards.
+type failOnce struct {
+	err    error
+	r      io.Reader
+	failed bool
+}
+
+func (f *failOnce) Read(p []byte) (int, error) {
+	if !f.failed {
+		f.failed = true
+		return 0, f.err
+	}
+
+	return f.r.Read(p)
+}
+
+func TestInterpreter(t *testing.T) {
+	instructions := DefaultGrammar.Tokenize("xmul(2,4)&mul[3,7]!^don't()_mul(5,5)+mul(32,64](mul(11,8)undo()?mul(8,5))")
+
+	assert(t, Interpreter{Semantics: Unconditional}.Run(instructions), 161, "unconditional result")
+	assert(t, Interpreter{Semantics: Conditional}.Run(instructions), 48, "conditional result")
+
+	var out bytes.Buffer
+	Interpreter{Semantics: Conditional, Trace: &out}.Run(instructions[:4])
+	assert(t, out.String(), ""+
+		"       1  mul(2,4)        enabled   executed  8\n"+
+		"      20  don't()         enabled   executed  8\n"+
+		"      28  mul(5,5)        disabled  skipped   8\n"+
+		"      48  mul(11,8)       disabled  skipped   8\n", "incorrect trace")
+
+	// semantics counting the instructions, whatever they are
+	RegisterSemantics("count", func(s *State, ins Instruction) bool {
+		s.Acc++
+		return true
+	})
+	defer delete(semantics, "count")
+
+	count, found := LookupSemantics("count")
+	assert(t, found, true, "registered semantics should be found")
+	assert(t, Interpreter{Semantics: count}.Run(instructions), 6, "count result")
+	assert(t, SemanticsNames(), []string{"conditional", "count", "unconditional"}, "incorrect names")
+
+	// a custom grammar may define mul with any number of arguments
+	odd := append(
+		NewGrammar(Definition{Name: InstructionTypeMul, Args: 1, MaxDigits: 3}).Tokenize("mul(7)"),
+		NewGrammar(Definition{Name: InstructionTypeMul}).Tokenize("mul()")...,
+	)
+	assert(t, len(odd), 2, "incorrect number of instructions")
+	assert(t, Interpreter{Semantics: Unconditional}.Run(odd), 0, "mul without two arguments shouldn't be executed")
+}
+
+func TestDisassembleCommand(t *testing.T) {
+	c, found := aoc.LookupCommand(3, "disassemble")
+	assert(t, found, true, "disassemble command should be registered")
+
+	var out bytes.Buffer
+	err := c.Run(strings.NewReader("mul(4*mul(2,4)?do()"), &out, []string{"-diagnostics"})
+	assert(t, err, nil, "unexpected error")
+	assert(t, out.String(), ""+
+		"       0  ; rejected \"mul(4*\": expected ','\n"+
+		"       6  mul(2,4)\n"+
+		"      15  do()\n", "incorrect listing")
+}
+
+func TestGrammar_Register(t *testing.T) {
+	g := NewGrammar(
+		Definition{Name: "add", Args: 2, MaxDigits: 2},
+		Definition{Name: "sum", Args: 4, MaxDigits: 1},
+		Definition{Name: "nop"},
+	)
+
+	instructions := g.Tokenize("add(1,99)sum(1,2,3,4)sum(1,2,3)add(100,1)nop()mul(2,4)")
+	assert(t, instructions, []Instruction{
+		{Position: 0, Typ: "add", Args: []int{1, 99}},
+		{Position: 9, Typ: "sum", Args: []int{1, 2, 3, 4}},
+		{Position: 41, Typ: "nop"},
+	}, "incorrect instructions")
+
+	var rendered []string
+	for _, ins := range instructions {
+		rendered = append(rendered, ins.String())
+	}
+	assert(t, rendered, []string{"add(1,99)", "sum(1,2,3,4)", "nop()"}, "incorrect rendering")
+
+	d, found := g.Lookup("sum")
+	assert(t, found, true, "sum should be registered")
+	assert(t, d.Args, 4, "incorrect number of arguments")
+
+	// arguments of 18 digits still fit in an int
+	g.Register(Definition{Name: "big", Args: 1, MaxDigits: 18})
+	assert(t, g.Tokenize("big(999999999999999999)")[0].Args, []int{999999999999999999}, "incorrect large argument")
+
+	assertPanics(t, func() { g.Register(Definition{Name: "huge", Args: 1, MaxDigits: 19}) }, "arguments that overflow should panic")
+	assertPanics(t, func() { g.Register(Definition{Name: "nop"}) }, "registering an instruction twice should panic")
+}
+
+func assertPanics(t *testing.T, f func(), msg string) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("assertion failed: %s", msg)
+		}
+	}()
+
+	f()
+}
+
+func FuzzParseInput(f *testing.F) {
+	aoctest.FuzzSeeds(f, generate)
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		parseInput(bytes.NewReader(input))
+	})
+}
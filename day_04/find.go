@@ -0,0 +1,73 @@
+package day04
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"aoc24/geom"
+	"aoc24/grid"
+)
+
+var directionNames = map[geom.Vector]string{
+	geom.DirectionNorth:     "north",
+	geom.DirectionNorthEast: "north-east",
+	geom.DirectionEast:      "east",
+	geom.DirectionSouthEast: "south-east",
+	geom.DirectionSouth:     "south",
+	geom.DirectionSouthWest: "south-west",
+	geom.DirectionWest:      "west",
+	geom.DirectionNorthWest: "north-west",
+}
+
+// find is the "find" command, listing where the given words are found and
+// optionally drawing the grid with only the letters of those words.
+func find(input io.Reader, out io.Writer, args []string) error {
+	var (
+		fs        = flag.NewFlagSet("find", flag.ContinueOnError)
+		words     = fs.String("words", "XMAS", "comma separated words to find")
+		highlight = fs.Bool("highlight", false, "draw the grid, leaving out the letters that aren't part of a word")
+	)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	g, err := parseInput(input)
+	if err != nil {
+		return err
+	}
+
+	var search [][]byte
+	for _, word := range strings.Split(*words, ",") {
+		search = append(search, []byte(word))
+	}
+
+	var (
+		ws      = grid.NewWordSearch(search...)
+		matches = ws.Find(&g)
+		found   = map[geom.Vector]bool{}
+	)
+
+	for _, m := range matches {
+		word := ws.Words()[m.Word]
+		fmt.Fprintf(out, "%s at %d,%d heading %s\n", word, m.Pos.X, m.Pos.Y, directionNames[m.Direction])
+
+		for _, pos := range m.Cells(len(word)) {
+			found[pos] = true
+		}
+	}
+	fmt.Fprintf(out, "%d matches\n", len(matches))
+
+	if *highlight {
+		fmt.Fprintf(out, "\n%s\n", g.Render(func(pos geom.Vector, cell byte) string {
+			if !found[pos] {
+				return "."
+			}
+
+			return string(cell)
+		}))
+	}
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package day04
+
+import (
+	"math/rand/v2"
+	"strings"
+)
+
+// generate produces a word search of size by size letters, picked from the
+// letters of XMAS so the words show up regularly.
+func generate(rng *rand.Rand, size int) []byte {
+	const letters = "XMAS"
+
+	var b strings.Builder
+	for range size {
+		for range size {
+			b.WriteByte(letters[rng.IntN(len(letters))])
+		}
+
+		b.WriteByte('\n')
+	}
+
+	return []byte(b.String())
+}
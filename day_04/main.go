@@ -1,168 +1,70 @@
-package main
+package day04
 
 import (
-	"fmt"
 	"io"
 	"iter"
-	"log"
-	"os"
-	"strings"
-)
-
-type Grid struct {
-	contents []byte
-	width    int
-	height   int
-}
-
-func (g Grid) String() string {
-	return string(g.contents)
-}
-
-func (g *Grid) CharAt(x, y int) byte {
-	idx := y*g.width + x
-	return g.contents[idx]
-}
-
-func (g *Grid) Horizontals() iter.Seq[[]byte] {
-	return func(yield func([]byte) bool) {
-		for y := 0; y < g.height; y++ {
-			start := y * g.width
-			end := start + g.width
-			if !yield(g.contents[start:end]) {
-				break
-			}
-		}
-	}
-}
-
-func (g *Grid) Verticals() iter.Seq[[]byte] {
-	return func(yield func([]byte) bool) {
-		for x := 0; x < g.width; x++ {
-			v := make([]byte, 0, g.height)
-			for y := 0; y < g.height; y++ {
-				v = append(v, g.CharAt(x, y))
-			}
-
-			if !yield(v) {
-				break
-			}
-		}
-	}
-}
-
-// Diagonal returns a single diagonal that starts at the given x and y position.
-// the direction integer determines the direction of the slice,
-//
-// passing +1 iterates through left-to-right
-// passing -1 iterates through right-to-left
-func (g *Grid) Diagonal(x, y int, direction int) []byte {
-	line := []byte{}
-	for x >= 0 && y >= 0 && x < g.width && y < g.height {
-		line = append(line, g.CharAt(x, y))
-		x += direction
-		y += 1
-	}
-	return line
-}
-
-func (g *Grid) Diagonals() iter.Seq[[]byte] {
-	return func(yield func([]byte) bool) {
-		// iterate left-to-right
-		x, y := 0, g.height-1
-		for y >= 0 && x < g.width {
-			if !yield(g.Diagonal(x, y, +1)) {
-				return
-			}
 
-			if y > 0 {
-				y--
-			} else {
-				x++
-			}
-		}
-
-		// iterate right-to-left
-		x, y = g.width-1, g.height-1
-		for y >= 0 && x >= 0 {
-			if !yield(g.Diagonal(x, y, -1)) {
-				return
-			}
+	"aoc24/aoc"
+	"aoc24/grid"
+)
 
-			if y > 0 {
-				y--
-			} else {
-				x--
-			}
-		}
-	}
+func init() {
+	aoc.Register(4, Solver{})
+	aoc.RegisterGenerator(4, generate)
+	aoc.RegisterCommand(4, aoc.Command{
+		Name:    "match",
+		Summary: "find shapes described by a template",
+		Run:     match,
+	})
+	aoc.RegisterCommand(4, aoc.Command{
+		Name:    "find",
+		Summary: "find words in every direction and highlight them",
+		Run:     find,
+	})
 }
 
-func (g *Grid) Subgrid(x, y int, width, height int) Grid {
-	var (
-		contents = make([]byte, 0, width*height)
-	)
+type Solver struct{}
 
-	for innerY := range height {
-		for innerX := range width {
-			contents = append(contents, g.CharAt(x+innerX, y+innerY))
-		}
+func (Solver) PartOne(input io.Reader) (int, error) {
+	g, err := parseInput(input)
+	if err != nil {
+		return 0, err
 	}
 
-	return Grid{
-		contents: contents,
-		width:    width,
-		height:   height,
-	}
+	return solvePartOne(g), nil
 }
 
-func main() {
-	input, err := io.ReadAll(os.Stdin)
+func (Solver) PartTwo(input io.Reader) (int, error) {
+	g, err := parseInput(input)
 	if err != nil {
-		log.Fatalf("unable to read input: %v", err)
+		return 0, err
 	}
 
-	grid := ParseGrid(string(input))
-	solvePartOne(grid)
-	solvePartTwo(grid)
+	return solvePartTwo(g), nil
+}
 
+func parseInput(input io.Reader) (grid.Grid[byte], error) {
+	return grid.ParseBytes(input)
 }
 
-func solvePartOne(g Grid) {
-	combined := CombineIter(
-		g.Horizontals(),
-		g.Verticals(),
-		g.Diagonals(),
-	)
+var (
+	xmas = []byte("XMAS")
+	samx = []byte("SAMX")
+)
 
-	xmasCount := 0
-	for line := range combined {
-		xmasCount += strings.Count(string(line), "XMAS")
-		xmasCount += strings.Count(string(line), "SAMX")
+// solvePartOne counts XMAS in every direction by searching every row,
+// column and diagonal forwards and backwards, without copying any of them.
+func solvePartOne(g grid.Grid[byte]) int {
+	n := 0
+	for l := range g.Lines() {
+		n += grid.Count(l, xmas) + grid.Count(l, samx)
 	}
 
-	fmt.Println("Answer part one:", xmasCount)
+	return n
 }
 
-func solvePartTwo(g Grid) {
-	xmasCount := 0
-	windowSize := 3
-
-	for offsetY := range g.height - (windowSize - 1) {
-		for offsetX := range g.width - (windowSize - 1) {
-			sg := g.Subgrid(offsetX, offsetY, windowSize, windowSize)
-
-			diagonalOne := string(sg.Diagonal(0, 0, +1))
-			diagonalTwo := string(sg.Diagonal(2, 0, -1))
-
-			if (diagonalOne == "MAS" || diagonalOne == "SAM") &&
-				(diagonalTwo == "MAS" || diagonalTwo == "SAM") {
-				xmasCount++
-			}
-		}
-	}
-
-	fmt.Println("Answer part two:", xmasCount) // expect 9
+func solvePartTwo(g grid.Grid[byte]) int {
+	return len(shapes["x-mas"].Find(&g))
 }
 
 // CombineIter takes N iter.Seq[T] and returns a single one, concatening
@@ -172,23 +74,10 @@ func CombineIter[T any, I iter.Seq[T]](i ...I) iter.Seq[T] {
 		for _, itr := range i {
 			for item := range itr {
 				if !yield(item) {
-					break
+					return
 				}
 			}
 		}
 
 	}
 }
-
-func ParseGrid(input string) Grid {
-	var (
-		numLines = strings.Count(input, "\n")
-		numCols  = strings.Index(input, "\n")
-	)
-
-	return Grid{
-		contents: []byte(strings.ReplaceAll(input, "\n", "")),
-		width:    numCols,
-		height:   numLines,
-	}
-}
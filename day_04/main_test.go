@@ -1,142 +1,134 @@
-package main
+package day04
 
 import (
+	"bytes"
 	"fmt"
 	"reflect"
 	"slices"
+	"strings"
 	"testing"
+
+	"aoc24/aoc"
+	"aoc24/aoc/aoctest"
+	"aoc24/geom"
+	"aoc24/grid"
 )
 
 func assert(t *testing.T, a, b any, msg string) {
 	if !reflect.DeepEqual(a, b) {
-		t.Fatalf(fmt.Sprintf("assertion failed: %s. Expected %+v, got %+v", msg, b, a))
+		t.Fatalf("assertion failed: %s. Expected %+v, got %+v", msg, b, a)
 	}
 }
 
-func TestParseGrid(t *testing.T) {
-	g := ParseGrid("abc\ndef\nghi\n")
-	assert(t, g.width, 3, "grid width incorrect")
-	assert(t, g.height, 3, "grid height incorrect")
-	assert(t, g.CharAt(0, 0), byte('a'), "incorrect char")
-	assert(t, g.CharAt(1, 0), byte('b'), "incorrect char")
-	assert(t, g.CharAt(0, 1), byte('d'), "incorrect char")
-	assert(t, g.CharAt(2, 1), byte('f'), "incorrect char")
-	assert(t, g.CharAt(2, 2), byte('i'), "incorrect char")
+func TestParseInput(t *testing.T) {
+	g, err := parseInput(strings.NewReader("abc\ndef\nghi\n"))
+	assert(t, err, nil, "unexpected error")
+	assert(t, g.Width(), 3, "grid width incorrect")
+	assert(t, g.Height(), 3, "grid height incorrect")
+	assert(t, g.At(geom.Vector{X: 0, Y: 0}), byte('a'), "incorrect char")
+	assert(t, g.At(geom.Vector{X: 1, Y: 0}), byte('b'), "incorrect char")
+	assert(t, g.At(geom.Vector{X: 0, Y: 1}), byte('d'), "incorrect char")
+	assert(t, g.At(geom.Vector{X: 2, Y: 1}), byte('f'), "incorrect char")
+	assert(t, g.At(geom.Vector{X: 2, Y: 2}), byte('i'), "incorrect char")
 }
 
-func TestHorizontals(t *testing.T) {
-	g := ParseGrid("abc\ndef\nghi\n")
-	h := slices.Collect(g.Horizontals())
-	assert(t, len(h), 3, "incorrect length")
-	assert(t, h[0], []byte("abc"), "incorrect first line")
-	assert(t, h[1], []byte("def"), "incorrect second line")
-	assert(t, h[2], []byte("ghi"), "incorrect third line")
+func TestCombineIter(t *testing.T) {
+	iter1 := slices.Values([]int{1, 2, 3})
+	iter2 := slices.Values([]int{4, 5, 6})
+
+	combined := slices.Collect(CombineIter(iter1, iter2))
+	assert(t, combined, []int{1, 2, 3, 4, 5, 6}, "combine not combining")
+
+	for n := range CombineIter(iter1, iter2) {
+		if n == 2 {
+			break
+		}
+	}
 }
 
-func TestVerticals(t *testing.T) {
-	g := ParseGrid("abc\ndef\nghi\n")
-	h := slices.Collect(g.Verticals())
-	assert(t, len(h), 3, "incorrect length")
-	assert(t, h[0], []byte("adg"), "incorrect first line")
-	assert(t, h[1], []byte("beh"), "incorrect second line")
-	assert(t, h[2], []byte("cfi"), "incorrect third line")
+func TestFindCommand(t *testing.T) {
+	c, found := aoc.LookupCommand(4, "find")
+	assert(t, found, true, "find command should be registered")
+
+	var out bytes.Buffer
+	err := c.Run(strings.NewReader("..X...\n.SAMX.\n.A..A.\nXMAS.S\n.X....\n"), &out, []string{"-highlight"})
+	assert(t, err, nil, "unexpected error")
+	assert(t, out.String(), `XMAS at 2,0 heading south-east
+XMAS at 4,1 heading west
+XMAS at 0,3 heading east
+XMAS at 1,4 heading north
+4 matches
+
+..X...
+.SAMX.
+.A..A.
+XMAS.S
+.X....
+`, "incorrect output")
 }
 
-func TestDiagonal(t *testing.T) {
-	g := ParseGrid("abc\ndef\nghi\n")
+func TestMatchCommand(t *testing.T) {
+	c, found := aoc.LookupCommand(4, "match")
+	assert(t, found, true, "match command should be registered")
 
-	t.Run("left-to-right", func(t *testing.T) {
-		assert(t, g.Diagonal(0, 0, +1), []byte("aei"), "wrong diagonal")
-		assert(t, g.Diagonal(1, 0, +1), []byte("bf"), "wrong diagonal")
-		assert(t, g.Diagonal(2, 0, +1), []byte("c"), "wrong diagonal")
-		assert(t, g.Diagonal(0, 1, +1), []byte("dh"), "wrong diagonal")
-		assert(t, g.Diagonal(0, 2, +1), []byte("g"), "wrong diagonal")
-	})
+	input := "M.S.M\n.A.A.\nM.S.M\n"
 
-	t.Run("right-to-left", func(t *testing.T) {
-		fmt.Printf("diagonal = %s\n", g.Diagonal(2, 2, -1))
-		assert(t, g.Diagonal(2, 0, -1), []byte("ceg"), "wrong diagonal")
-		assert(t, g.Diagonal(1, 0, -1), []byte("bd"), "wrong diagonal")
-		assert(t, g.Diagonal(0, 0, -1), []byte("a"), "wrong diagonal")
-		assert(t, g.Diagonal(2, 1, -1), []byte("fh"), "wrong diagonal")
-		assert(t, g.Diagonal(2, 2, -1), []byte("i"), "wrong diagonal")
-	})
+	var out bytes.Buffer
+	err := c.Run(strings.NewReader(input), &out, nil)
+	assert(t, err, nil, "unexpected error")
+	assert(t, out.String(), "0,0\n2,0\n2 matches\n", "incorrect output")
+
+	out.Reset()
+	err = c.Run(strings.NewReader(input), &out, []string{"-template", "M.S/.A./M.S", "-rotate=false", "-reflect=false"})
+	assert(t, err, nil, "unexpected error")
+	assert(t, out.String(), "0,0\n1 matches\n", "incorrect output")
+
+	err = c.Run(strings.NewReader(input), &out, []string{"-shape", "x-wing"})
+	assert(t, err != nil, true, "unknown shape should fail")
 }
 
-func TestDiagonals(t *testing.T) {
-	g := ParseGrid("abc\ndef\nghi\n")
-	h := slices.Collect(g.Diagonals())
-
-	// a b c
-	// d e f
-	// g h i
-	//
-	// bcomes =
-	//  left to right       right to left
-	// - g                   i
-	// - d h                 f h
-	// - a e i               c e g
-	// - b f                 b d
-	// - c                   a
-	assert(t, len(h), 10, "incorrect length")
-	assert(t, h[0], []byte("g"), "incorrect first line")
-	assert(t, h[1], []byte("dh"), "incorrect second line")
-	assert(t, h[2], []byte("aei"), "incorrect third line")
-	assert(t, h[3], []byte("bf"), "incorrect third line")
-	assert(t, h[4], []byte("c"), "incorrect third line")
-	assert(t, h[5], []byte("i"), "incorrect first line")
-	assert(t, h[6], []byte("fh"), "incorrect second line")
-	assert(t, h[7], []byte("ceg"), "incorrect third line")
-	assert(t, h[8], []byte("bd"), "incorrect third line")
-	assert(t, h[9], []byte("a"), "incorrect third line")
+func TestSolvePartOne(t *testing.T) {
+	// the word search finds every occurrence on its own, without views
+	ws := grid.NewWordSearch([]byte("XMAS"))
+
+	for seed := range uint64(10) {
+		g, err := parseInput(bytes.NewReader(generate(aoc.NewRand(seed), 20)))
+		assert(t, err, nil, "unexpected error")
+		assert(t, solvePartOne(g), len(ws.Find(&g)), "lines and word search disagree")
+	}
 }
 
-func TestSubgrid(t *testing.T) {
-	// entire grid:
-	//
-	// a b c d e
-	// f g h i j
-	// k l m n o
-	// p q r s t
-	// u v w x y
-
-	g := ParseGrid("abcde\nfghij\nklmno\npqrst\nuvwxy\n")
-
-	// subgrid (0,0,3,3):
-	// [a b c] d e
-	// [f g h] i j
-	// [k l m] n o
-	//  p q r  s t
-	//  u v w  x y
-	sg := g.Subgrid(0, 0, 3, 3)
-	assert(t, sg.width, 3, "subgrid width incorrect")
-	assert(t, sg.height, 3, "subgrid height incorrect")
-	assert(t, sg.CharAt(0, 0), byte('a'), "incorrect char in subgrid")
-	assert(t, sg.CharAt(1, 1), byte('g'), "incorrect char in subgrid")
-	assert(t, sg.CharAt(2, 2), byte('m'), "incorrect char in subgrid")
-
-	// subgrid (2,2,3,3):
-	// a b  c d e
-	// f g  h i j
-	// k l [m n o]
-	// p q [r s t]
-	// u v [w x y]
-	sg = g.Subgrid(2, 2, 3, 3)
-	assert(t, sg.width, 3, "subgrid width incorrect")
-	assert(t, sg.height, 3, "subgrid height incorrect")
-	assert(t, sg.CharAt(0, 0), byte('m'), "incorrect char in subgrid")
-	assert(t, sg.CharAt(1, 1), byte('s'), "incorrect char in subgrid")
-	assert(t, sg.CharAt(2, 2), byte('y'), "incorrect char in subgrid")
-
-	// subgrid diagonals
-	assert(t, sg.Diagonal(0, 0, 1), []byte("msy"), "incorrect subgrid diagonal")
-	assert(t, sg.Diagonal(2, 0, -1), []byte("osw"), "incorrect subgrid diagonal")
+// BenchmarkPartOne compares counting XMAS along views of the lines of the
+// grid against the word search, which allocates a match per occurrence.
+func BenchmarkPartOne(b *testing.B) {
+	ws := grid.NewWordSearch([]byte("XMAS"))
+
+	for _, size := range []int{100, 1000} {
+		g, err := parseInput(bytes.NewReader(generate(aoc.NewRand(1), size)))
+		if err != nil {
+			b.Fatalf("unable to parse generated input: %v", err)
+		}
+
+		b.Run(fmt.Sprintf("lines/size=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				solvePartOne(g)
+			}
+		})
+
+		b.Run(fmt.Sprintf("word-search/size=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				ws.Find(&g)
+			}
+		})
+	}
 }
 
-func TestCombineIter(t *testing.T) {
-	iter1 := slices.Values([]int{1, 2, 3})
-	iter2 := slices.Values([]int{4, 5, 6})
+func FuzzParseInput(f *testing.F) {
+	aoctest.FuzzSeeds(f, generate)
 
-	combined := slices.Collect(CombineIter(iter1, iter2))
-	assert(t, combined, []int{1, 2, 3, 4, 5, 6}, "combine not combining")
+	f.Fuzz(func(t *testing.T, input []byte) {
+		parseInput(bytes.NewReader(input))
+	})
 }
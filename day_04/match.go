@@ -0,0 +1,80 @@
+package day04
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"aoc24/grid"
+)
+
+// templates describes shapes of letters, where '.' matches any letter.
+var templates = map[string]string{
+	// two MAS crossing diagonally, as in part two
+	"x-mas": "M.S\n.A.\nM.S",
+
+	// two MAS crossing horizontally and vertically
+	"plus-mas": ".M.\nMAS\n.S.",
+}
+
+// shapes holds the patterns of the templates, in any orientation.
+var shapes = map[string]grid.Pattern[byte]{}
+
+func init() {
+	for name, s := range templates {
+		t, err := grid.ParseTemplate(s, '.')
+		if err != nil {
+			panic(fmt.Errorf("invalid template %s: %w", name, err))
+		}
+
+		shapes[name] = grid.NewPattern(t, true, true)
+	}
+}
+
+// match is the "match" command, finding the areas matched by a template,
+// either one of the named shapes or one given row by row, separated by '/'.
+func match(input io.Reader, out io.Writer, args []string) error {
+	var (
+		fs          = flag.NewFlagSet("match", flag.ContinueOnError)
+		shape       = fs.String("shape", "x-mas", "named shape to find: "+strings.Join(slices.Sorted(maps.Keys(templates)), " or "))
+		template    = fs.String("template", "", "template to find instead of a named shape, such as M.S/.A./M.S where . matches any letter")
+		rotations   = fs.Bool("rotate", true, "match the template rotated by any number of quarter turns")
+		reflections = fs.Bool("reflect", true, "match the template mirrored")
+	)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	s := *template
+	if s == "" {
+		var found bool
+		if s, found = templates[*shape]; !found {
+			return fmt.Errorf("unknown shape %q", *shape)
+		}
+	}
+
+	t, err := grid.ParseTemplate(strings.ReplaceAll(s, "/", "\n"), '.')
+	if err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+
+	g, err := parseInput(input)
+	if err != nil {
+		return err
+	}
+
+	var (
+		p       = grid.NewPattern(t, *rotations, *reflections)
+		matches = p.Find(&g)
+	)
+
+	for _, m := range matches {
+		fmt.Fprintf(out, "%d,%d\n", m.Pos.X, m.Pos.Y)
+	}
+	fmt.Fprintf(out, "%d matches\n", len(matches))
+
+	return nil
+}
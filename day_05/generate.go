@@ -0,0 +1,50 @@
+package day05
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// generate produces an ordering of 49 pages, written as rules for every pair
+// of pages, followed by size updates of an odd number of pages. About half of
+// the updates are in the correct order.
+func generate(rng *rand.Rand, size int) []byte {
+	order := rng.Perm(89)[:49]
+	for idx := range order {
+		order[idx] += 10
+	}
+
+	var b strings.Builder
+	rules := rng.Perm(len(order) * len(order))
+	for _, r := range rules {
+		left, right := r/len(order), r%len(order)
+		if left < right {
+			fmt.Fprintf(&b, "%d|%d\n", order[left], order[right])
+		}
+	}
+
+	b.WriteByte('\n')
+
+	for range size {
+		// pick pages while keeping their relative order
+		positions := rng.Perm(len(order))[:5+2*rng.IntN(10)]
+		if rng.IntN(2) == 0 {
+			slices.Sort(positions)
+		}
+
+		for idx, pos := range positions {
+			if idx > 0 {
+				b.WriteByte(',')
+			}
+
+			b.WriteString(strconv.Itoa(order[pos]))
+		}
+
+		b.WriteByte('\n')
+	}
+
+	return []byte(b.String())
+}
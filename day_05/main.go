@@ -1,14 +1,15 @@
-package main
+package day05
 
 import (
 	"fmt"
 	"io"
-	"log"
+	"iter"
 	"math"
-	"os"
 	"slices"
-	"strconv"
 	"strings"
+
+	"aoc24/aoc"
+	"aoc24/parse"
 )
 
 type PrioMap map[int][]int
@@ -59,24 +60,54 @@ func (p PrioMap) Scope(nums []int) PrioMap {
 	return m
 }
 
-func main() {
-	input, err := io.ReadAll(os.Stdin)
+func init() {
+	aoc.Register(5, Solver{})
+	aoc.RegisterGenerator(5, generate)
+}
+
+type Solver struct{}
+
+func (Solver) PartOne(input io.Reader) (int, error) {
+	updates, rules, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
+
+	return partOne(updates, rules), nil
+}
+
+func (Solver) PartTwo(input io.Reader) (int, error) {
+	updates, rules, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
+
+	return partTwo(updates, rules), nil
+}
+
+func parseInput(input io.Reader) ([]Update, PrioMap, error) {
+	r := parse.NewReader(input)
+
+	// the rules run up to the empty line, the updates follow after it
+	prioMap, err := parseRules(r.Lines())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updates, err := parseUpdates(r.Lines())
 	if err != nil {
-		log.Fatalf("unable to read input: %v", err)
+		return nil, nil, err
 	}
 
-	rules, updates, ok := strings.Cut(string(input), "\n\n")
-	if !ok {
-		log.Fatalf("unable to parse in head and updates section")
+	if err := r.Err(); err != nil {
+		return nil, nil, err
 	}
 
-	var (
-		prioMap       = parseRules(rules)
-		parsedUpdates = parseUpdates(updates)
-	)
+	if len(updates) == 0 {
+		return nil, nil, fmt.Errorf("unable to parse in head and updates section")
+	}
 
-	fmt.Println("Answer part one = ", partOne(parsedUpdates, prioMap))
-	fmt.Println("Answer part two = ", partTwo(parsedUpdates, prioMap))
+	return updates, prioMap, nil
 }
 
 func partOne(updates []Update, rules PrioMap) int {
@@ -104,14 +135,28 @@ func partTwo(updates []Update, rules PrioMap) int {
 	return sum
 }
 
-func parseRules(input string) PrioMap {
+// parseRules parses rules until the first empty line.
+func parseRules(lines iter.Seq[parse.Line]) (PrioMap, error) {
 	m := PrioMap{}
 
-	for line := range slices.Values(strings.Split(input, "\n")) {
-		var left, right int
-		_, err := fmt.Sscanf(line, "%d|%d", &left, &right)
+	for line := range lines {
+		if line.Text == "" {
+			break
+		}
+
+		leftText, rightText, ok := strings.Cut(line.Text, "|")
+		if !ok {
+			return nil, parse.Errorf(line.Number, 1, line.Text, "expected a rule in the form of X|Y")
+		}
+
+		left, err := parse.Field{Text: leftText, Column: 1}.Int(line.Number)
+		if err != nil {
+			return nil, err
+		}
+
+		right, err := parse.Field{Text: rightText, Column: len(leftText) + 2}.Int(line.Number)
 		if err != nil {
-			panic(fmt.Errorf("error parsing line \"%s\": %w", line, err))
+			return nil, err
 		}
 
 		if _, present := m[left]; !present {
@@ -125,26 +170,35 @@ func parseRules(input string) PrioMap {
 		m[left] = append(m[left], right)
 	}
 
-	return m
+	return m, nil
 }
 
-func parseUpdates(input string) []Update {
+func parseUpdates(lines iter.Seq[parse.Line]) ([]Update, error) {
 	var updates []Update
-	for _, line := range strings.Split(strings.TrimSpace(input), "\n") {
-		var u Update
-		digits := strings.Split(line, ",")
-		for _, d := range digits {
-			num, err := strconv.Atoi(d)
+	for line := range lines {
+		if line.Text == "" {
+			continue
+		}
+
+		var (
+			u      Update
+			column = 1
+		)
+
+		for _, d := range strings.Split(line.Text, ",") {
+			num, err := parse.Field{Text: d, Column: column}.Int(line.Number)
 			if err != nil {
-				panic(fmt.Errorf("error parsing %s: %w", d, err))
+				return nil, err
 			}
+
 			u = append(u, num)
+			column += len(d) + 1
 		}
 
 		updates = append(updates, u)
 	}
 
-	return updates
+	return updates, nil
 }
 
 type numeric interface {
@@ -1,7 +1,12 @@
-package main
+package day05
 
 import (
+	"bytes"
+	"strings"
 	"testing"
+
+	"aoc24/aoc/aoctest"
+	"aoc24/parse"
 )
 
 func assert(t *testing.T, statement bool, message string) {
@@ -38,7 +43,8 @@ func TestUpdate_MiddlePageNumber(t *testing.T) {
 }
 
 func TestPrioMap_Scope(t *testing.T) {
-	p := parseRules(priomap)
+	p, err := parseRules(parse.NewReader(strings.NewReader(priomap)).Lines())
+	assert(t, err == nil, "unexpected error parsing rules")
 	updates := []Update{
 		[]int{75, 47, 61, 53, 29},
 		[]int{97, 61, 53, 29, 13},
@@ -53,3 +59,11 @@ func TestPrioMap_Scope(t *testing.T) {
 		assert(t, len(s) == len(u), "incorrect scope")
 	}
 }
+
+func FuzzParseInput(f *testing.F) {
+	aoctest.FuzzSeeds(f, generate)
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		parseInput(bytes.NewReader(input))
+	})
+}
@@ -0,0 +1,58 @@
+package day06
+
+import (
+	"math/rand/v2"
+
+	"aoc24/geom"
+	"aoc24/grid"
+)
+
+// generate produces a lab of size by size cells with scattered obstructions
+// and the guard facing north. Labs in which the guard gets stuck in a loop
+// are thrown away, as the guard is supposed to leave the lab eventually.
+func generate(rng *rand.Rand, size int) []byte {
+	for {
+		g := grid.New[CellType](size, size)
+		for pos := range g.All() {
+			cell := CellTypeOpen
+			if rng.IntN(50) == 0 {
+				cell = CellTypeBlocked
+			}
+
+			g.Set(pos, cell)
+		}
+
+		guard := geom.Vector{X: rng.IntN(size), Y: rng.IntN(size)}
+		g.Set(guard, CellTypeOpen)
+		if !leaves(g, guard) {
+			continue
+		}
+
+		g.Set(guard, CellTypeGuard)
+		rendered := g.Render(func(_ geom.Vector, cell CellType) string {
+			return string(cell)
+		})
+
+		return []byte(rendered + "\n")
+	}
+}
+
+// leaves reports whether the guard walks out of the lab.
+func leaves(g Grid, guard geom.Vector) bool {
+	w := Walker{
+		grid:    g,
+		pos:     guard,
+		heading: geom.HeadingNorth,
+	}
+
+	walked := map[Step]struct{}{}
+	for step := range w.Walk() {
+		if _, found := walked[step]; found {
+			return false
+		}
+
+		walked[step] = struct{}{}
+	}
+
+	return true
+}
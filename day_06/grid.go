@@ -1,46 +1,33 @@
-package main
+package day06
 
 import (
-	"slices"
+	"fmt"
+
+	"aoc24/geom"
+	"aoc24/grid"
 )
 
 type CellType rune
 
 const (
 	CellTypeOpen    CellType = '.'
-	CellTypeBlocked          = '#'
-	CellTypeGuard            = '^'
+	CellTypeBlocked CellType = '#'
+	CellTypeGuard   CellType = '^'
 )
 
-type Grid struct {
-	width  int
-	height int
-	cells  []CellType
-}
-
-func (g *Grid) Clone() Grid {
-	return Grid{
-		width:  g.width,
-		height: g.height,
-		cells:  slices.Clone(g.cells),
-	}
-}
-
-func (g *Grid) WithinBounds(loc Vector) bool {
-	return loc.X < g.width &&
-		loc.Y < g.height &&
-		loc.X >= 0 &&
-		loc.Y >= 0
-}
+type Grid = grid.Grid[CellType]
 
-func (g *Grid) CellAt(pos Vector) CellType {
-	return g.cells[pos.Y*g.width+pos.X]
-}
+// parseCell converts a single character of the input into a cell. The guard
+// starts out on an open cell, its position is reported through guardFn.
+func parseCell(pos geom.Vector, c byte, guardFn func(geom.Vector)) (CellType, error) {
+	switch CellType(c) {
+	case CellTypeOpen, CellTypeBlocked:
+		return CellType(c), nil
 
-func (g *Grid) SetCellAt(pos Vector, typ CellType) {
-	g.cells[pos.Y*g.width+pos.X] = typ
-}
+	case CellTypeGuard:
+		guardFn(pos)
+		return CellTypeOpen, nil
+	}
 
-func (g *Grid) CellAtPositionWalkable(pos Vector) bool {
-	return g.CellAt(pos) == CellTypeOpen
+	return 0, fmt.Errorf("invalid cell")
 }
@@ -1,73 +1,76 @@
-package main
+package day06
 
 import (
-	"bufio"
 	"fmt"
 	"io"
 	"maps"
-	"os"
 	"slices"
 	"sync"
-	"time"
+
+	"aoc24/aoc"
+	"aoc24/geom"
+	"aoc24/grid"
 )
 
-func main() {
-	grid, startingPos := parseInput(os.Stdin)
+func init() {
+	aoc.Register(6, Solver{})
+	aoc.RegisterGenerator(6, generate)
+}
 
-	start := time.Now()
+type Solver struct{}
 
-	path, numUniqueLocs := partOne(grid, startingPos)
-	fmt.Println("Answer part one = ", numUniqueLocs)
-	fmt.Println("Answer part two = ", partTwo(grid, startingPos, path))
+func (Solver) PartOne(input io.Reader) (int, error) {
+	g, startingPos, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
 
-	duration := time.Since(start)
-	fmt.Println("found answer in", duration)
+	_, numUniqueLocs := partOne(g, startingPos)
+	return numUniqueLocs, nil
 }
 
-func parseInput(input io.Reader) (Grid, Vector) {
+func (Solver) PartTwo(input io.Reader) (int, error) {
+	g, startingPos, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
+
+	path, _ := partOne(g, startingPos)
+	return partTwo(g, startingPos, path), nil
+}
+
+func parseInput(input io.Reader) (Grid, geom.Vector, error) {
 	var (
-		res         Grid
-		pos         Vector
-		startingPos Vector
+		startingPos geom.Vector
+		guards      int
 	)
 
-	scanner := bufio.NewScanner(input)
-	for scanner.Scan() {
-		line := scanner.Text()
-		res.width = len(line)
-		for _, char := range line {
-			switch char {
-			case '.':
-				res.cells = append(res.cells, CellTypeOpen)
-
-			case '#':
-				res.cells = append(res.cells, CellTypeBlocked)
-
-			case '^':
-				res.cells = append(res.cells, CellTypeOpen)
-				startingPos = pos
-			}
-
-			pos.X++
-		}
+	g, err := grid.Parse(input, func(pos geom.Vector, c byte) (CellType, error) {
+		return parseCell(pos, c, func(guard geom.Vector) {
+			startingPos = guard
+			guards++
+		})
+	})
+	if err != nil {
+		return Grid{}, geom.Vector{}, err
+	}
 
-		pos.Y++
-		pos.X = 0
+	if guards != 1 {
+		return Grid{}, geom.Vector{}, fmt.Errorf("expected a single guard, found %d", guards)
 	}
-	res.height = pos.Y
 
-	return res, startingPos
+	return g, startingPos, nil
 }
 
-func partOne(grid Grid, startingPos Vector) ([]Step, int) {
+func partOne(g Grid, startingPos geom.Vector) ([]Step, int) {
 	w := Walker{
-		grid:    grid,
+		grid:    g,
 		pos:     startingPos,
-		heading: HeadingNorth,
+		heading: geom.HeadingNorth,
 	}
 
 	path := slices.Collect(w.Walk())
-	coords := make([]Vector, 0, len(path))
+	coords := make([]geom.Vector, 0, len(path))
 	for _, step := range path {
 		coords = append(coords, step.Pos)
 	}
@@ -82,22 +85,22 @@ func unique[T comparable](s []T) []T {
 	return slices.Collect(maps.Keys(unique))
 }
 
-func partTwo(grid Grid, startingPos Vector, path []Step) int {
+func partTwo(g Grid, startingPos geom.Vector, path []Step) int {
 	var (
-		candidateChan = make(chan Vector)
+		candidateChan = make(chan geom.Vector)
 		wg            sync.WaitGroup
 	)
 
 	for _, step := range slices.Backward(path) {
 		wg.Add(1)
-		go func(grid Grid, step Step) {
+		go func(g Grid, step Step) {
 			defer wg.Done()
-			g := grid.Clone()
-			g.SetCellAt(step.Pos, CellTypeBlocked)
+			g = g.Clone()
+			g.Set(step.Pos, CellTypeBlocked)
 			w := Walker{
 				grid:    g,
 				pos:     startingPos,
-				heading: HeadingNorth,
+				heading: geom.HeadingNorth,
 			}
 
 			walked := map[Step]struct{}{}
@@ -108,10 +111,10 @@ func partTwo(grid Grid, startingPos Vector, path []Step) int {
 				}
 				walked[woot] = struct{}{}
 			}
-		}(grid, step)
+		}(g, step)
 	}
 
-	candidates := []Vector{}
+	candidates := []geom.Vector{}
 	go func() {
 		for coord := range candidateChan {
 			candidates = append(candidates, coord)
@@ -0,0 +1,19 @@
+package day06
+
+import (
+	"bytes"
+	"testing"
+
+	"aoc24/aoc/aoctest"
+)
+
+func FuzzParseInput(f *testing.F) {
+	aoctest.FuzzSeeds(f, generate)
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		g, start, err := parseInput(bytes.NewReader(input))
+		if err == nil && g.Height() > 0 && !g.InBounds(start) {
+			t.Fatalf("guard at %+v outside the lab", start)
+		}
+	})
+}
@@ -1,16 +1,20 @@
-package main
+package day06
 
-import "iter"
+import (
+	"iter"
+
+	"aoc24/geom"
+)
 
 type Walker struct {
 	grid    Grid
-	pos     Vector
-	heading Heading
+	pos     geom.Vector
+	heading geom.Heading
 }
 
 type Step struct {
-	Pos     Vector
-	Heading Heading
+	Pos     geom.Vector
+	Heading geom.Heading
 }
 
 func (w *Walker) Walk() iter.Seq[Step] {
@@ -26,11 +30,11 @@ func (w *Walker) Walk() iter.Seq[Step] {
 			}
 
 			nextPos := w.nextPos()
-			if !w.grid.WithinBounds(nextPos) {
+			if !w.grid.InBounds(nextPos) {
 				return
 			}
 
-			if !w.grid.CellAtPositionWalkable(nextPos) {
+			if w.grid.At(nextPos) != CellTypeOpen {
 				w.heading = w.heading.RotateClockwise()
 				continue
 			}
@@ -40,10 +44,10 @@ func (w *Walker) Walk() iter.Seq[Step] {
 	}
 }
 
-func (w *Walker) nextPos() Vector {
+func (w *Walker) nextPos() geom.Vector {
 	return w.pos.Add(w.heading.Vector())
 }
 
-func (w *Walker) moveTo(pos Vector) {
+func (w *Walker) moveTo(pos geom.Vector) {
 	w.pos = pos
 }
@@ -0,0 +1,60 @@
+package day07
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+// generate produces size equations of 2 to 12 numbers. The test value of
+// most equations is the result of applying random operators to the numbers,
+// the rest is off by one and most likely can't be produced at all.
+func generate(rng *rand.Rand, size int) []byte {
+	// stay well clear of overflowing, real inputs stay below 10^15 as well
+	const limit = 1_000_000_000_000
+
+	var b strings.Builder
+	for range size {
+		var (
+			count = 2 + rng.IntN(11)
+			nums  = []int{1 + rng.IntN(999)}
+			total = nums[0]
+		)
+
+		for len(nums) < count {
+			num := 1 + rng.IntN(rng.IntN(999)+1)
+
+			var next int
+			switch AvailableOps[rng.IntN(len(AvailableOps))] {
+			case OpAdd:
+				next = total + num
+
+			case OpMul:
+				next = total * num
+
+			case OpCat:
+				next = intCat(total, num)
+			}
+
+			if next > limit {
+				break
+			}
+
+			nums = append(nums, num)
+			total = next
+		}
+
+		if rng.IntN(3) == 0 {
+			total++
+		}
+
+		fmt.Fprintf(&b, "%d:", total)
+		for _, num := range nums {
+			fmt.Fprintf(&b, " %d", num)
+		}
+
+		b.WriteByte('\n')
+	}
+
+	return []byte(b.String())
+}
@@ -1,13 +1,11 @@
-package main
+package day07
 
 import (
-	"bufio"
-	"fmt"
 	"io"
-	"os"
-	"strconv"
 	"strings"
-	"time"
+
+	"aoc24/aoc"
+	"aoc24/parse"
 )
 
 type Op int
@@ -29,16 +27,29 @@ type Equation struct {
 	Parts []int
 }
 
-func main() {
-	equations := parseInput(os.Stdin)
+func init() {
+	aoc.Register(7, Solver{})
+	aoc.RegisterGenerator(7, generate)
+}
 
-	p1Stop := profile("part one")
-	fmt.Println("answer part one =", solve(equations, AvailableOps[0:2]))
-	p1Stop()
+type Solver struct{}
 
-	p2Stop := profile("part two")
-	fmt.Println("answer part two =", solve(equations, AvailableOps))
-	p2Stop()
+func (Solver) PartOne(input io.Reader) (int, error) {
+	equations, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
+
+	return solve(equations, AvailableOps[0:2]), nil
+}
+
+func (Solver) PartTwo(input io.Reader) (int, error) {
+	equations, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
+
+	return solve(equations, AvailableOps), nil
 }
 
 func solve(equations []Equation, availableOps []Op) int {
@@ -103,32 +114,42 @@ func check(target, total int, nums []int, ops []Op) bool {
 	return false
 }
 
-func parseInput(input io.Reader) []Equation {
+func parseInput(input io.Reader) ([]Equation, error) {
 	var (
-		output  []Equation
-		scanner = bufio.NewScanner(input)
+		output []Equation
+		r      = parse.NewReader(input)
 	)
 
-	for scanner.Scan() {
-		line := scanner.Text()
-		parts := strings.Split(line, ":")
-		sum, err := strconv.Atoi(parts[0])
+	for l := range r.Lines() {
+		var (
+			line   = l.Text
+			lineNo = l.Number
+		)
+
+		if len(line) == 0 {
+			continue
+		}
+
+		sumText, partsText, found := strings.Cut(line, ":")
+		if !found {
+			return nil, parse.Errorf(lineNo, 1, line, "expected an equation in the form of \"sum: parts\"")
+		}
+
+		sum, err := parse.Field{Text: sumText, Column: 1}.Int(lineNo)
 		if err != nil {
-			panic(fmt.Errorf("error parsing integer %+v: %w", parts[0], err))
+			return nil, err
 		}
 
 		var l Equation
 		l.Sum = sum
 
-		for _, part := range strings.Split(parts[1], " ") {
-			part = strings.TrimSpace(part)
-			if part == "" {
-				continue
-			}
+		for _, part := range parse.Fields(partsText) {
+			// columns of the parts are relative to the text after the colon
+			part.Column += len(sumText) + 1
 
-			p, err := strconv.Atoi(part)
+			p, err := part.Int(lineNo)
 			if err != nil {
-				panic(fmt.Errorf("error parsing integer '%+v': %w", part, err))
+				return nil, err
 			}
 
 			l.Parts = append(l.Parts, p)
@@ -137,12 +158,5 @@ func parseInput(input io.Reader) []Equation {
 		output = append(output, l)
 	}
 
-	return output
-}
-
-func profile(label string) func() {
-	start := time.Now()
-	return func() {
-		fmt.Printf("profile %s took %+v\n", label, time.Since(start))
-	}
+	return output, r.Err()
 }
@@ -0,0 +1,34 @@
+package day07
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"aoc24/aoc/aoctest"
+)
+
+func assert(t *testing.T, a, b any, msg string) {
+	t.Helper()
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("assertion failed: %s. Expected %+v, got %+v", msg, b, a)
+	}
+}
+
+func TestParseInput(t *testing.T) {
+	equations, err := parseInput(strings.NewReader("190: 10 19\n\n3267: 81 40 27\n\n"))
+	assert(t, err, nil, "blank lines should be skipped")
+	assert(t, equations, []Equation{
+		{Sum: 190, Parts: []int{10, 19}},
+		{Sum: 3267, Parts: []int{81, 40, 27}},
+	}, "incorrect equations")
+}
+
+func FuzzParseInput(f *testing.F) {
+	aoctest.FuzzSeeds(f, generate)
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		parseInput(bytes.NewReader(input))
+	})
+}
@@ -0,0 +1,25 @@
+package day08
+
+import (
+	"math/rand/v2"
+
+	"aoc24/grid"
+)
+
+// generate produces a map of size by size cells, where about one in every 25
+// cells holds an antenna tuned to one of 36 frequencies.
+func generate(rng *rand.Rand, size int) []byte {
+	const frequencies = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJ0123456789"
+
+	g := grid.New[byte](size, size)
+	for pos := range g.All() {
+		cell := byte('.')
+		if rng.IntN(25) == 0 {
+			cell = frequencies[rng.IntN(36)]
+		}
+
+		g.Set(pos, cell)
+	}
+
+	return []byte(g.String() + "\n")
+}
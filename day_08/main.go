@@ -1,62 +1,58 @@
-package main
+package day08
 
 import (
-	"bufio"
-	"fmt"
 	"io"
-	"os"
-	"time"
-)
-
-type Vector struct {
-	X, Y int
-}
+	"unicode"
 
-func (v Vector) Sub(v2 Vector) Vector {
-	return Vector{
-		X: v.X - v2.X,
-		Y: v.Y - v2.Y,
-	}
-}
-
-func (v Vector) Add(v2 Vector) Vector {
-	return Vector{
-		X: v.X + v2.X,
-		Y: v.Y + v2.Y,
-	}
-}
+	"aoc24/aoc"
+	"aoc24/geom"
+	"aoc24/parse"
+)
 
 type Arena struct {
 	width     int
 	height    int
-	locByFreq map[rune][]Vector
+	locByFreq map[rune][]geom.Vector
 }
 
 func NewArena() Arena {
 	return Arena{
-		locByFreq: make(map[rune][]Vector),
+		locByFreq: make(map[rune][]geom.Vector),
 	}
 }
 
-func (a *Arena) withinBounds(v Vector) bool {
+func (a *Arena) withinBounds(v geom.Vector) bool {
 	return v.X >= 0 && v.X < a.width &&
 		v.Y >= 0 && v.Y < a.height
 }
 
-func main() {
-	arena := parseInput(os.Stdin)
+func init() {
+	aoc.Register(8, Solver{})
+	aoc.RegisterGenerator(8, generate)
+}
+
+type Solver struct{}
 
-	start := time.Now()
-	fmt.Println("answer part one =", solve(arena, false))
-	fmt.Printf("part one took %+v\n", time.Since(start))
+func (Solver) PartOne(input io.Reader) (int, error) {
+	arena, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
 
-	start = time.Now()
-	fmt.Println("answer part two =", solve(arena, true))
-	fmt.Printf("part two took %+v", time.Since(start))
+	return solve(arena, false), nil
+}
+
+func (Solver) PartTwo(input io.Reader) (int, error) {
+	arena, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
+
+	return solve(arena, true), nil
 }
 
 func solve(a Arena, resonance bool) int {
-	antiNodes := map[Vector]struct{}{}
+	antiNodes := map[geom.Vector]struct{}{}
 	for _, locs := range a.locByFreq {
 		for _, pair := range pairs(locs) {
 
@@ -113,15 +109,15 @@ func pairs[T any](s []T) [][2]T {
 	return res
 }
 
-func parseInput(input io.Reader) Arena {
+func parseInput(input io.Reader) (Arena, error) {
 	var (
 		arena    = NewArena()
-		scanner  = bufio.NewScanner(input)
+		r        = parse.NewReader(input)
 		row, col int
 	)
 
-	for scanner.Scan() {
-		cells := scanner.Text()
+	for line := range r.Lines() {
+		cells := line.Text
 		arena.width = len(cells)
 		col = 0
 
@@ -131,12 +127,16 @@ func parseInput(input io.Reader) Arena {
 				continue
 			}
 
+			if !unicode.IsLetter(cell) && !unicode.IsDigit(cell) {
+				return Arena{}, parse.Errorf(row+1, col+1, string(cell), "invalid antenna frequency")
+			}
+
 			if _, present := arena.locByFreq[cell]; !present {
-				arena.locByFreq[cell] = []Vector{}
+				arena.locByFreq[cell] = []geom.Vector{}
 			}
 
 			tmp := arena.locByFreq[cell]
-			tmp = append(tmp, Vector{X: col, Y: row})
+			tmp = append(tmp, geom.Vector{X: col, Y: row})
 			arena.locByFreq[cell] = tmp
 			col++
 		}
@@ -144,5 +144,5 @@ func parseInput(input io.Reader) Arena {
 	}
 
 	arena.height = row
-	return arena
+	return arena, r.Err()
 }
@@ -0,0 +1,21 @@
+package day09
+
+import (
+	"math/rand/v2"
+)
+
+// generate produces a disk map of size digits, alternating between files of
+// 1 to 9 blocks and free space of 0 to 9 blocks.
+func generate(rng *rand.Rand, size int) []byte {
+	diskMap := make([]byte, 0, size+1)
+	for idx := range size {
+		if idx%2 == 0 {
+			diskMap = append(diskMap, '1'+byte(rng.IntN(9)))
+			continue
+		}
+
+		diskMap = append(diskMap, '0'+byte(rng.IntN(10)))
+	}
+
+	return append(diskMap, '\n')
+}
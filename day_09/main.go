@@ -1,14 +1,14 @@
-package main
+package day09
 
 import (
-	"bufio"
 	"container/list"
 	"fmt"
 	"io"
-	"os"
 	"strconv"
 	"strings"
-	"time"
+
+	"aoc24/aoc"
+	"aoc24/parse"
 )
 
 type BlockType uint8
@@ -172,6 +172,14 @@ func (b *Blocks) CompactWithoutFragmentation() {
 			if fileBlock.Size == freeBlock.Size {
 				newNodePos := fileElm.Next()
 				b.list.MoveBefore(fileElm, node)
+
+				// the file may have been the last block on the disk
+				if newNodePos == nil {
+					b.list.MoveToBack(node)
+					fileElm = node
+					break
+				}
+
 				b.list.MoveBefore(node, newNodePos)
 				fileElm = newNodePos
 				break
@@ -197,8 +205,9 @@ func (b *Blocks) CompactWithFragmentation() {
 		freeElm, freeBlock, freeIdx := b.FindBlockOfTypeAfter(BlockTypeFree, b.list.Front())
 		fileElm, fileBlock, fileIdx := b.FindBlockOfTypeBefore(BlockTypeFile, b.list.Back())
 
-		// exit the loop as soon as the first free block is past the first file block.
-		if freeIdx > fileIdx {
+		// exit the loop when there's no free space or no files left to move,
+		// or as soon as the first free block is past the first file block.
+		if freeBlock == nil || fileBlock == nil || freeIdx > fileIdx {
 			break
 		}
 
@@ -262,39 +271,49 @@ func partTwo(blocks Blocks) int {
 	return blocks.Checksum()
 }
 
-func main() {
-	blocks := parseInput(os.Stdin)
-	blocks2 := blocks.Clone()
+func init() {
+	aoc.Register(9, Solver{})
+	aoc.RegisterGenerator(9, generate)
+}
+
+type Solver struct{}
 
-	start := time.Now()
-	fmt.Println("answer part one =", partOne(blocks))
-	fmt.Printf("part one took %+v\n", time.Since(start))
+func (Solver) PartOne(input io.Reader) (int, error) {
+	blocks, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
+
+	return partOne(blocks), nil
+}
+
+func (Solver) PartTwo(input io.Reader) (int, error) {
+	blocks, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
 
-	start = time.Now()
-	fmt.Println("answer part two =", partTwo(blocks2))
-	fmt.Printf("part two took %+v\n", time.Since(start))
+	return partTwo(blocks), nil
 }
 
-func parseInput(input io.Reader) Blocks {
+func parseInput(input io.Reader) (Blocks, error) {
 	var (
-		scanner = bufio.NewScanner(input)
-		blocks  = Blocks{
+		r      = parse.NewReader(input)
+		blocks = Blocks{
 			list: list.New(),
 		}
 		blockType = BlockTypeFile
 		fileID    = 0
 	)
 
-	scanner.Split(bufio.ScanRunes)
-	for scanner.Scan() {
-		char := scanner.Text()
-		if char == "\n" {
+	for char := range r.Runes() {
+		if char.Value == '\n' {
 			continue
 		}
 
-		num, err := strconv.ParseUint(char, 10, 8)
+		num, err := strconv.ParseUint(string(char.Value), 10, 8)
 		if err != nil {
-			panic(fmt.Errorf("error converting %s to int: %w", char, err))
+			return Blocks{}, parse.Errorf(char.Line, char.Column, string(char.Value), "not a digit")
 		}
 
 		if blockType == BlockTypeFile {
@@ -311,5 +330,5 @@ func parseInput(input io.Reader) Blocks {
 		}
 	}
 
-	return blocks
+	return blocks, r.Err()
 }
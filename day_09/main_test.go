@@ -0,0 +1,168 @@
+package day09
+
+import (
+	"bytes"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"aoc24/aoc/aoctest"
+	"aoc24/difftest"
+)
+
+func FuzzParseInput(f *testing.F) {
+	aoctest.FuzzSeeds(f, generate)
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		parseInput(bytes.NewReader(input))
+	})
+}
+
+// expand lays out the disk map block by block, holding the file id of every
+// block or -1 for free space.
+func expand(diskMap string) []int {
+	var disk []int
+	for idx, c := range diskMap {
+		id := -1
+		if idx%2 == 0 {
+			id = idx / 2
+		}
+
+		for range int(c - '0') {
+			disk = append(disk, id)
+		}
+	}
+
+	return disk
+}
+
+func checksum(disk []int) int {
+	var sum int
+	for idx, id := range disk {
+		if id >= 0 {
+			sum += idx * id
+		}
+	}
+
+	return sum
+}
+
+// compactBlocks moves single blocks from the end of the disk to the leftmost
+// free block.
+func compactBlocks(disk []int) int {
+	for left, right := 0, len(disk)-1; left < right; {
+		switch {
+		case disk[left] >= 0:
+			left++
+
+		case disk[right] < 0:
+			right--
+
+		default:
+			disk[left], disk[right] = disk[right], disk[left]
+		}
+	}
+
+	return checksum(disk)
+}
+
+// compactFiles moves whole files, starting with the highest id, to the
+// leftmost span of free blocks that fits them.
+func compactFiles(disk []int) int {
+	maxID := slices.Max(append(slices.Clone(disk), -1))
+	for id := maxID; id >= 0; id-- {
+		start := slices.Index(disk, id)
+		size := 0
+		for start+size < len(disk) && disk[start+size] == id {
+			size++
+		}
+
+		for free := 0; free+size <= start; free++ {
+			if slices.ContainsFunc(disk[free:free+size], func(b int) bool { return b >= 0 }) {
+				continue
+			}
+
+			for idx := range size {
+				disk[free+idx], disk[start+idx] = id, -1
+			}
+
+			break
+		}
+	}
+
+	return checksum(disk)
+}
+
+func TestDifferentialCompact(t *testing.T) {
+	c := difftest.Case[string, int]{
+		Generate: func(rng *rand.Rand) string {
+			return strings.TrimSpace(string(generate(rng, 1+rng.IntN(30))))
+		},
+		Shrink: func(diskMap string) iter.Seq[string] {
+			return func(yield func(string) bool) {
+				// drop files along with the free space following them, then
+				// shrink single files and free space
+				for idx := len(diskMap) - 1; idx >= 0; idx-- {
+					if idx%2 == 0 && !yield(diskMap[:idx]+diskMap[min(idx+2, len(diskMap)):]) {
+						return
+					}
+				}
+
+				for idx := range diskMap {
+					size := int(diskMap[idx] - '0')
+					for shrunk := range difftest.ShrinkInt(size) {
+						if !yield(diskMap[:idx] + strconv.Itoa(shrunk) + diskMap[idx+1:]) {
+							return
+						}
+					}
+				}
+			}
+		},
+		// real disk maps never hold empty files
+		Feasible: func(diskMap string) bool {
+			for idx := 0; idx < len(diskMap); idx += 2 {
+				if diskMap[idx] == '0' {
+					return false
+				}
+			}
+
+			return true
+		},
+	}
+
+	blocks := func(t *testing.T, diskMap string) Blocks {
+		b, err := parseInput(strings.NewReader(diskMap))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		return b
+	}
+
+	t.Run("with fragmentation", func(t *testing.T) {
+		c.Naive = func(diskMap string) int {
+			return compactBlocks(expand(diskMap))
+		}
+
+		c.Optimized = func(diskMap string) int {
+			return partOne(blocks(t, diskMap))
+		}
+
+		c.Run(t, 2000)
+	})
+
+	t.Run("without fragmentation", func(t *testing.T) {
+		c.Naive = func(diskMap string) int {
+			return compactFiles(expand(diskMap))
+		}
+
+		c.Optimized = func(diskMap string) int {
+			return partTwo(blocks(t, diskMap))
+		}
+
+		c.Run(t, 2000)
+	})
+}
@@ -0,0 +1,35 @@
+package day10
+
+import (
+	"math/rand/v2"
+
+	"aoc24/geom"
+	"aoc24/grid"
+)
+
+// generate produces a topographic map of size by size positions. Random
+// heights alone hardly ever form a hiking trail, so on top of those about
+// size trails are laid out, each climbing from height 0 to 9 one step at a
+// time.
+func generate(rng *rand.Rand, size int) []byte {
+	g := grid.New[byte](size, size)
+	for pos := range g.All() {
+		g.Set(pos, '0'+byte(rng.IntN(10)))
+	}
+
+	for range size {
+		pos := geom.Vector{X: rng.IntN(size), Y: rng.IntN(size)}
+		for height := byte('0'); height <= '9'; height++ {
+			g.Set(pos, height)
+
+			next := pos.Add(geom.OrthogonalDirections[rng.IntN(4)])
+			if !g.InBounds(next) {
+				break
+			}
+
+			pos = next
+		}
+	}
+
+	return []byte(g.String() + "\n")
+}
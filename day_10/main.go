@@ -1,50 +1,50 @@
-package main
+package day10
 
 import (
-	"bufio"
 	"fmt"
 	"io"
 	"maps"
-	"os"
-	"slices"
 	"strconv"
-	"strings"
-	"time"
+
+	"aoc24/aoc"
+	"aoc24/geom"
+	"aoc24/grid"
 )
 
 var (
 	CellTrailHead = uint8(0)
 	CellTrailPeak = uint8(9)
+)
+
+func init() {
+	aoc.Register(10, Solver{})
+	aoc.RegisterGenerator(10, generate)
+}
 
-	DirectionNorth = Vector{X: 0, Y: -1}
-	DirectionEast  = Vector{X: 1, Y: 0}
-	DirectionSouth = Vector{X: 0, Y: 1}
-	DirectionWest  = Vector{X: -1, Y: 0}
+type Solver struct{}
 
-	NeighbouringDirections = []Vector{
-		DirectionNorth,
-		DirectionEast,
-		DirectionSouth,
-		DirectionWest,
+func (Solver) PartOne(input io.Reader) (int, error) {
+	m, err := parseInput(input)
+	if err != nil {
+		return 0, err
 	}
-)
 
-func main() {
-	m := parseInput(os.Stdin)
+	return partOne(m), nil
+}
 
-	start := time.Now()
-	fmt.Println(" answer part one =", partOne(m))
-	fmt.Printf("part one took %+v\n", time.Since(start))
+func (Solver) PartTwo(input io.Reader) (int, error) {
+	m, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
 
-	start = time.Now()
-	fmt.Println("answer part two =", partTwo(m))
-	fmt.Printf("part two took %+v", time.Since(start))
+	return partTwo(m), nil
 }
 
 func partOne(m Map) int {
 	sum := 0
 	for _, h := range m.TrailHeads {
-		p := ReachablePeaks(m, h, []Vector{})
+		p := ReachablePeaks(m, h, []geom.Vector{})
 		if len(p) > 0 {
 			sum += len(unique(p))
 		}
@@ -60,7 +60,7 @@ func partOne(m Map) int {
 func partTwo(m Map) int {
 	sum := 0
 	for _, h := range m.TrailHeads {
-		p := ReachablePeaks(m, h, []Vector{})
+		p := ReachablePeaks(m, h, []geom.Vector{})
 		for n := range maps.Values(freq(p)) {
 			sum += n
 		}
@@ -98,12 +98,12 @@ func unique[T comparable](s []T) []T {
 	return res
 }
 
-func ReachablePeaks(m Map, pos Vector, peaks []Vector) []Vector {
-	if m.CellAtPos(pos) == CellTrailPeak {
+func ReachablePeaks(m Map, pos geom.Vector, peaks []geom.Vector) []geom.Vector {
+	if m.At(pos) == CellTrailPeak {
 		return append(peaks, pos)
 	}
 
-	var reachable []Vector
+	var reachable []geom.Vector
 
 	for _, n := range m.ValidNeighbours(pos) {
 		if p := ReachablePeaks(m, n, peaks); len(p) > 0 {
@@ -114,52 +114,23 @@ func ReachablePeaks(m Map, pos Vector, peaks []Vector) []Vector {
 	return reachable
 }
 
-type Vector struct {
-	X, Y int
-}
-
-func (v Vector) Add(v2 Vector) Vector {
-	return Vector{
-		X: v.X + v2.X,
-		Y: v.Y + v2.Y,
-	}
-}
-
 type Map struct {
-	Cells  []uint8
-	Width  int
-	Height int
+	grid.Grid[uint8]
 
-	TrailHeads []Vector
-}
-
-func (m *Map) PosInBounds(pos Vector) bool {
-	return pos.X >= 0 && pos.Y >= 0 &&
-		pos.X < m.Width && pos.Y < m.Height
-}
-
-func (m *Map) CellAtPos(pos Vector) uint8 {
-	if !m.PosInBounds(pos) {
-		panic(fmt.Errorf("position out of bounds"))
-	}
-
-	idx := pos.Y*m.Width + pos.X
-	return m.Cells[idx]
+	TrailHeads []geom.Vector
 }
 
 // ValidNeighbours returns the neighbours based off of the current
 // position that are both in the map as well as exactly one level higher.
-func (m *Map) ValidNeighbours(pos Vector) []Vector {
+func (m *Map) ValidNeighbours(pos geom.Vector) []geom.Vector {
 	var (
-		res        = make([]Vector, 0, 4)
-		currentVal = m.CellAtPos(pos)
+		res        = make([]geom.Vector, 0, 4)
+		currentVal = m.At(pos)
 	)
 
-	for dir := range slices.Values(NeighbouringDirections) {
-		neighbour := pos.Add(dir)
-
-		// neighbouring cell within bounds and exactly one level higher?
-		if m.PosInBounds(neighbour) && m.CellAtPos(neighbour)-currentVal == 1 {
+	for neighbour := range m.Neighbours4(pos) {
+		// neighbouring cell exactly one level higher?
+		if m.At(neighbour)-currentVal == 1 {
 			res = append(res, neighbour)
 		}
 	}
@@ -168,47 +139,27 @@ func (m *Map) ValidNeighbours(pos Vector) []Vector {
 }
 
 func (m *Map) String() string {
-	var s strings.Builder
-	for idx, v := range m.Cells {
-
-		if idx > 0 && idx%m.Width == 0 {
-			s.WriteString("\n")
-		}
-		s.WriteString(fmt.Sprintf("%d", v))
-	}
-
-	return s.String()
+	return m.Render(func(_ geom.Vector, v uint8) string {
+		return fmt.Sprintf("%d", v)
+	})
 }
 
-func parseInput(input io.Reader) Map {
-	var (
-		scanner  = bufio.NewScanner(input)
-		output   Map
-		col, row int
-	)
-
-	for scanner.Scan() {
-		cells := scanner.Text()
-		output.Width = len(cells)
-		col = 0
-
-		for _, cell := range cells {
-			num, err := strconv.ParseUint(string(cell), 10, 8)
-			if err != nil {
-				panic(fmt.Errorf("error converting %s to int: %w", string(cell), err))
-			}
-
-			// find trailheads
-			if uint8(num) == CellTrailHead {
-				output.TrailHeads = append(output.TrailHeads, Vector{X: col, Y: row})
-			}
+func parseInput(input io.Reader) (Map, error) {
+	var output Map
+	g, err := grid.Parse(input, func(pos geom.Vector, cell byte) (uint8, error) {
+		num, err := strconv.ParseUint(string(cell), 10, 8)
+		if err != nil {
+			return 0, fmt.Errorf("not a height")
+		}
 
-			output.Cells = append(output.Cells, uint8(num))
-			col++
+		// find trailheads
+		if uint8(num) == CellTrailHead {
+			output.TrailHeads = append(output.TrailHeads, pos)
 		}
-		row++
-	}
 
-	output.Height = row
-	return output
+		return uint8(num), nil
+	})
+
+	output.Grid = g
+	return output, err
 }
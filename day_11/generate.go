@@ -0,0 +1,18 @@
+package day11
+
+import (
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+// generate produces a line of size stones, engraved with numbers of up to 6
+// digits.
+func generate(rng *rand.Rand, size int) []byte {
+	stones := make([]string, size)
+	for idx := range stones {
+		stones[idx] = strconv.Itoa(rng.IntN(1_000_000))
+	}
+
+	return []byte(strings.Join(stones, " ") + "\n")
+}
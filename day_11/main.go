@@ -1,13 +1,11 @@
-package main
+package day11
 
 import (
-	"bufio"
-	"fmt"
 	"io"
 	"math"
-	"os"
-	"strconv"
-	"time"
+
+	"aoc24/aoc"
+	"aoc24/parse"
 )
 
 type Stones struct {
@@ -43,16 +41,29 @@ func (s *Stones) Count() int {
 	return total
 }
 
-func main() {
-	stones := parseInput(os.Stdin)
+func init() {
+	aoc.Register(11, Solver{})
+	aoc.RegisterGenerator(11, generate)
+}
+
+type Solver struct{}
 
-	start := time.Now()
-	fmt.Println("answer part one =", partOne(stones))
-	fmt.Printf("part one took %+v\n", time.Since(start))
+func (Solver) PartOne(input io.Reader) (int, error) {
+	stones, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
 
-	start = time.Now()
-	fmt.Println("answer part two =", partTwo(stones))
-	fmt.Printf("part two took %+v", time.Since(start))
+	return partOne(stones), nil
+}
+
+func (Solver) PartTwo(input io.Reader) (int, error) {
+	stones, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
+
+	return partTwo(stones), nil
 }
 
 func blink(num int) []int {
@@ -87,33 +98,31 @@ func partOne(s Stones) int {
 }
 
 func partTwo(s Stones) int {
-	// blinking 50 more times since we already blinked 25 times for part one
-	for i := 0; i < 50; i++ {
+	for i := 0; i < 75; i++ {
 		s.Blink()
-
 	}
 
 	return s.Count()
 }
 
-func parseInput(input io.Reader) Stones {
+func parseInput(input io.Reader) (Stones, error) {
 	var (
-		scanner = bufio.NewScanner(input)
-		res     = Stones{
+		r   = parse.NewReader(input)
+		res = Stones{
 			m: make(map[int]int),
 		}
 	)
 
-	scanner.Split(bufio.ScanWords)
-	for scanner.Scan() {
-		val := scanner.Text()
-		num, err := strconv.Atoi(val)
-		if err != nil {
-			panic(fmt.Errorf("error converting %s to int: %w", val, err))
-		}
+	for line := range r.Lines() {
+		for _, field := range parse.Fields(line.Text) {
+			num, err := field.Int(line.Number)
+			if err != nil {
+				return Stones{}, err
+			}
 
-		res.m[num] = 1
+			res.m[num]++
+		}
 	}
 
-	return res
+	return res, r.Err()
 }
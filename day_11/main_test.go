@@ -0,0 +1,93 @@
+package day11
+
+import (
+	"bytes"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"aoc24/aoc/aoctest"
+	"aoc24/difftest"
+)
+
+func FuzzParseInput(f *testing.F) {
+	aoctest.FuzzSeeds(f, generate)
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		parseInput(bytes.NewReader(input))
+	})
+}
+
+func TestDifferentialBlink(t *testing.T) {
+	type input struct {
+		Stones []int
+		Blinks int
+	}
+
+	difftest.Case[input, int]{
+		Generate: func(rng *rand.Rand) input {
+			stones := make([]int, 1+rng.IntN(8))
+			for idx := range stones {
+				// small numbers, so some stones are engraved the same
+				stones[idx] = rng.IntN(rng.IntN(1000) + 1)
+			}
+
+			return input{Stones: stones, Blinks: rng.IntN(26)}
+		},
+		Shrink: func(in input) iter.Seq[input] {
+			return func(yield func(input) bool) {
+				for blinks := range difftest.ShrinkInt(in.Blinks) {
+					if !yield(input{in.Stones, blinks}) {
+						return
+					}
+				}
+
+				stones := difftest.Concat(
+					difftest.ShrinkSlice(in.Stones),
+					difftest.ShrinkElements(in.Stones, difftest.ShrinkInt),
+				)
+
+				for s := range stones {
+					if !yield(input{s, in.Blinks}) {
+						return
+					}
+				}
+			}
+		},
+		// blinks stone by stone, keeping every stone in a list
+		Naive: func(in input) int {
+			stones := slices.Clone(in.Stones)
+			for range in.Blinks {
+				var next []int
+				for _, s := range stones {
+					next = append(next, blink(s)...)
+				}
+
+				stones = next
+			}
+
+			return len(stones)
+		},
+		// parses the stones and counts them by their number
+		Optimized: func(in input) int {
+			var text []string
+			for _, s := range in.Stones {
+				text = append(text, strconv.Itoa(s))
+			}
+
+			stones, err := parseInput(strings.NewReader(strings.Join(text, " ")))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for range in.Blinks {
+				stones.Blink()
+			}
+
+			return stones.Count()
+		},
+	}.Run(t, 200)
+}
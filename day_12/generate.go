@@ -0,0 +1,29 @@
+package day12
+
+import (
+	"math/rand/v2"
+
+	"aoc24/geom"
+	"aoc24/grid"
+)
+
+// generate produces a garden of size by size plots growing one of 26 plants.
+// Plots mostly grow the same plant as the plot to their left or above them,
+// so the garden consists of regions of various shapes rather than noise.
+func generate(rng *rand.Rand, size int) []byte {
+	g := grid.New[byte](size, size)
+	for pos := range g.All() {
+		plant := 'A' + byte(rng.IntN(26))
+		switch n := rng.IntN(10); {
+		case n < 4 && pos.X > 0:
+			plant = g.At(pos.Add(geom.DirectionWest))
+
+		case n < 8 && pos.Y > 0:
+			plant = g.At(pos.Add(geom.DirectionNorth))
+		}
+
+		g.Set(pos, plant)
+	}
+
+	return []byte(g.String() + "\n")
+}
@@ -1,34 +1,19 @@
-package main
+package day12
 
 import (
-	"bufio"
 	"fmt"
 	"io"
 	"iter"
 	"maps"
-	"os"
 	"slices"
-	"time"
-)
 
-var (
-	NeighbourNorth = Vector{X: 0, Y: -1}
-	NeighbourEast  = Vector{X: 1, Y: 0}
-	NeighbourSouth = Vector{X: 0, Y: 1}
-	NeighbourWest  = Vector{X: -1, Y: 0}
-
-	OrthogonalNeighbouringDirections = []Vector{
-		NeighbourNorth,
-		NeighbourEast,
-		NeighbourSouth,
-		NeighbourWest,
-	}
+	"aoc24/aoc"
+	"aoc24/geom"
+	"aoc24/grid"
 )
 
 type Grid struct {
-	Tiles  []byte
-	Width  int
-	Height int
+	grid.Grid[byte]
 }
 type Borders uint8
 
@@ -47,24 +32,24 @@ const (
 	BordersWest
 )
 
-func (g *Grid) BordersAt(loc Vector) Borders {
+func (g *Grid) BordersAt(loc geom.Vector) Borders {
 	var b Borders
 	selfTyp := g.At(loc)
 
-	for _, dir := range OrthogonalNeighbouringDirections {
+	for _, dir := range geom.OrthogonalDirections {
 		neighbourLoc := loc.Add(dir)
-		if !g.WithinBounds(neighbourLoc) || g.At(neighbourLoc) != selfTyp {
+		if typ, ok := g.Get(neighbourLoc); !ok || typ != selfTyp {
 			switch dir {
-			case NeighbourNorth:
+			case geom.DirectionNorth:
 				b ^= BordersNorth
 
-			case NeighbourEast:
+			case geom.DirectionEast:
 				b ^= BordersEast
 
-			case NeighbourSouth:
+			case geom.DirectionSouth:
 				b ^= BordersSouth
 
-			case NeighbourWest:
+			case geom.DirectionWest:
 				b ^= BordersWest
 			}
 		}
@@ -73,51 +58,22 @@ func (g *Grid) BordersAt(loc Vector) Borders {
 	return b
 }
 
-func (g *Grid) WithinBounds(pos Vector) bool {
-	return pos.X >= 0 && pos.Y >= 0 &&
-		pos.X < g.Width && pos.Y < g.Height
-}
-
-func (g *Grid) At(pos Vector) byte {
-	return g.Tiles[pos.Y*g.Width+pos.X]
-}
-
 func (g *Grid) Print() {
-	for idx, tile := range g.Tiles {
-		if idx > 0 && idx%g.Width == 0 {
-			fmt.Println()
-		}
-
-		fmt.Printf("%s", string(tile))
-	}
-
-	fmt.Println()
+	fmt.Println(g)
 }
 
-func (g *Grid) NeighboursOfType(pos Vector, typ byte) []Vector {
-	var neighbours []Vector
-	for _, dir := range OrthogonalNeighbouringDirections {
-		newPos := pos.Add(dir)
-		if g.WithinBounds(newPos) && g.At(newPos) == typ {
-			neighbours = append(neighbours, newPos)
+func (g *Grid) NeighboursOfType(pos geom.Vector, typ byte) []geom.Vector {
+	var neighbours []geom.Vector
+	for n := range g.Neighbours4(pos) {
+		if g.At(n) == typ {
+			neighbours = append(neighbours, n)
 		}
 	}
 
 	return neighbours
 }
 
-type Vector struct {
-	X, Y int
-}
-
-func (v Vector) Add(v2 Vector) Vector {
-	return Vector{
-		X: v.X + v2.X,
-		Y: v.Y + v2.Y,
-	}
-}
-
-type Cluster []Vector
+type Cluster []geom.Vector
 
 func (c Cluster) Area() int {
 	return len(c)
@@ -155,14 +111,14 @@ func (c Cluster) Sides(g Grid) int {
 		BordersWest | BordersNorth,
 	}
 
-	checkInnerCorner := func(loc, dirA, dirB Vector, expectedTyp byte) bool {
+	checkInnerCorner := func(loc, dirA, dirB geom.Vector, expectedTyp byte) bool {
 		a := loc.Add(dirA)
 		b := loc.Add(dirB)
 		c := loc.Add(dirA.Add(dirB))
 
-		return g.WithinBounds(a) && g.At(a) == expectedTyp &&
-			g.WithinBounds(b) && g.At(b) == expectedTyp &&
-			g.WithinBounds(c) && g.At(c) != expectedTyp
+		return g.InBounds(a) && g.At(a) == expectedTyp &&
+			g.InBounds(b) && g.At(b) == expectedTyp &&
+			g.InBounds(c) && g.At(c) != expectedTyp
 	}
 
 	for _, coord := range c {
@@ -175,19 +131,19 @@ func (c Cluster) Sides(g Grid) int {
 			}
 		}
 
-		if checkInnerCorner(coord, NeighbourSouth, NeighbourEast, selfTyp) {
+		if checkInnerCorner(coord, geom.DirectionSouth, geom.DirectionEast, selfTyp) {
 			total++
 		}
 
-		if checkInnerCorner(coord, NeighbourNorth, NeighbourEast, selfTyp) {
+		if checkInnerCorner(coord, geom.DirectionNorth, geom.DirectionEast, selfTyp) {
 			total++
 		}
 
-		if checkInnerCorner(coord, NeighbourSouth, NeighbourWest, selfTyp) {
+		if checkInnerCorner(coord, geom.DirectionSouth, geom.DirectionWest, selfTyp) {
 			total++
 		}
 
-		if checkInnerCorner(coord, NeighbourNorth, NeighbourWest, selfTyp) {
+		if checkInnerCorner(coord, geom.DirectionNorth, geom.DirectionWest, selfTyp) {
 			total++
 		}
 	}
@@ -211,33 +167,31 @@ func (c Cluster) Perimeter(g Grid) int {
 // where all directly adjecent coordinates are reachable when of the same type.
 type Clusterer struct {
 	grid      Grid
-	available map[Vector]struct{}
+	available map[geom.Vector]struct{}
 }
 
 func (c *Clusterer) Init(g Grid) {
-	available := map[Vector]struct{}{}
-	for y := 0; y < g.Height; y++ {
-		for x := 0; x < g.Width; x++ {
-			available[Vector{X: x, Y: y}] = struct{}{}
-		}
+	available := map[geom.Vector]struct{}{}
+	for pos := range g.All() {
+		available[pos] = struct{}{}
 	}
 
 	c.grid = g
 	c.available = available
 }
 
-func (c *Clusterer) nextAvailableStartingLocation() (Vector, bool) {
+func (c *Clusterer) nextAvailableStartingLocation() (geom.Vector, bool) {
 	next, stop := iter.Pull(maps.Keys(c.available))
 	defer stop()
 	loc, ok := next()
 	if !ok {
-		return Vector{}, false
+		return geom.Vector{}, false
 	}
 
 	return loc, ok
 }
 
-func (c *Clusterer) MarkUnavailable(locs ...Vector) {
+func (c *Clusterer) MarkUnavailable(locs ...geom.Vector) {
 	for _, loc := range locs {
 		delete(c.available, loc)
 	}
@@ -254,7 +208,7 @@ func (c *Clusterer) Clusters() []Cluster {
 
 		typ := c.grid.At(start)
 
-		cluster := map[Vector]struct{}{}
+		cluster := map[geom.Vector]struct{}{}
 		c.findCluster(start, typ, cluster)
 
 		locs := slices.Collect(maps.Keys(cluster))
@@ -265,10 +219,10 @@ func (c *Clusterer) Clusters() []Cluster {
 	return clusters
 }
 
-func (c *Clusterer) findCluster(start Vector, typ byte, cluster map[Vector]struct{}) {
+func (c *Clusterer) findCluster(start geom.Vector, typ byte, cluster map[geom.Vector]struct{}) {
 	cluster[start] = struct{}{}
 
-	neighbours := []Vector{}
+	neighbours := []geom.Vector{}
 	for _, n := range c.grid.NeighboursOfType(start, typ) {
 		if _, present := cluster[n]; present {
 			continue
@@ -290,62 +244,56 @@ func (c *Clusterer) findCluster(start Vector, typ byte, cluster map[Vector]struc
 	}
 }
 
-func main() {
-	grid := parseInput(os.Stdin)
+func init() {
+	aoc.Register(12, Solver{})
+	aoc.RegisterGenerator(12, generate)
+}
 
-	c := Clusterer{}
-	c.Init(grid)
-	clusters := c.Clusters()
+type Solver struct{}
+
+func (Solver) PartOne(input io.Reader) (int, error) {
+	g, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
+
+	return partOne(g, clusters(g)), nil
+}
+
+func (Solver) PartTwo(input io.Reader) (int, error) {
+	g, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
 
-	start := time.Now()
-	fmt.Println("answer part one =", partOne(grid, clusters))
-	fmt.Printf("part one took %+v\n", time.Since(start))
+	return partTwo(g, clusters(g)), nil
+}
 
-	start = time.Now()
-	fmt.Println("answer part two =", partTwo(grid, clusters))
-	fmt.Printf("part two took %+v", time.Since(start))
+func clusters(g Grid) []Cluster {
+	c := Clusterer{}
+	c.Init(g)
+	return c.Clusters()
 }
 
-func partOne(grid Grid, clusters []Cluster) int {
+func partOne(g Grid, clusters []Cluster) int {
 	total := 0
 	for _, c := range clusters {
-		total += c.Area() * c.Perimeter(grid)
+		total += c.Area() * c.Perimeter(g)
 	}
 
 	return total
 }
 
-func partTwo(grid Grid, clusters []Cluster) int {
+func partTwo(g Grid, clusters []Cluster) int {
 	total := 0
 	for _, c := range clusters {
-		total += c.Area() * c.Sides(grid)
+		total += c.Area() * c.Sides(g)
 	}
 
 	return total
 }
 
-func parseInput(input io.Reader) Grid {
-	var (
-		scanner = bufio.NewScanner(input)
-		grid    = Grid{}
-		x, y    int
-	)
-
-	scanner.Split(bufio.ScanBytes)
-	for scanner.Scan() {
-		tile := scanner.Text()[0]
-		if tile == '\n' {
-			y++
-			if grid.Width == 0 {
-				grid.Width = x
-			}
-			continue
-		}
-
-		grid.Tiles = append(grid.Tiles, tile)
-		x++
-	}
-
-	grid.Height = y
-	return grid
+func parseInput(input io.Reader) (Grid, error) {
+	g, err := grid.ParseBytes(input)
+	return Grid{g}, err
 }
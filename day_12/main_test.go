@@ -0,0 +1,16 @@
+package day12
+
+import (
+	"bytes"
+	"testing"
+
+	"aoc24/aoc/aoctest"
+)
+
+func FuzzParseInput(f *testing.F) {
+	aoctest.FuzzSeeds(f, generate)
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		parseInput(bytes.NewReader(input))
+	})
+}
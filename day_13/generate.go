@@ -0,0 +1,47 @@
+package day13
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+
+	"aoc24/geom"
+)
+
+// generate produces size claw machines. The prize of most machines can be
+// won by pressing each button at most 100 times, the prize of the others is
+// off by one and likely out of reach.
+func generate(rng *rand.Rand, size int) []byte {
+	button := func() geom.Vector {
+		return geom.Vector{X: 10 + rng.IntN(90), Y: 10 + rng.IntN(90)}
+	}
+
+	var b strings.Builder
+	for idx := range size {
+		var a, bb geom.Vector
+		for {
+			a, bb = button(), button()
+
+			// buttons moving the claw in the same direction have more than
+			// one way to reach the prize, which real inputs never have
+			if a.X*bb.Y != a.Y*bb.X {
+				break
+			}
+		}
+
+		prize := a.Scale(rng.IntN(101)).Add(bb.Scale(rng.IntN(101)))
+		if rng.IntN(3) == 0 {
+			prize.X++
+		}
+
+		if idx > 0 {
+			b.WriteByte('\n')
+		}
+
+		fmt.Fprintf(&b, "Button A: X+%d, Y+%d\n", a.X, a.Y)
+		fmt.Fprintf(&b, "Button B: X+%d, Y+%d\n", bb.X, bb.Y)
+		fmt.Fprintf(&b, "Prize: X=%d, Y=%d\n", prize.X, prize.Y)
+	}
+
+	return []byte(b.String())
+}
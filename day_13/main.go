@@ -1,50 +1,53 @@
-package main
+package day13
 
 import (
-	"bufio"
 	"fmt"
 	"io"
 	"math"
-	"os"
 	"strings"
-	"time"
+
+	"aoc24/aoc"
+	"aoc24/geom"
+	"aoc24/parse"
 )
 
 const PartTwoOffset = 10_000_000_000_000
 
-var PartTwoOffsetVector = Vector{
+var PartTwoOffsetVector = geom.Vector{
 	X: PartTwoOffset,
 	Y: PartTwoOffset,
 }
 
-type Vector struct {
-	X, Y int
+type Machine struct {
+	Prize   geom.Vector
+	ButtonA geom.Vector
+	ButtonB geom.Vector
 }
 
-func (v Vector) Add(v2 Vector) Vector {
-	return Vector{
-		X: v.X + v2.X,
-		Y: v.Y + v2.Y,
-	}
+func init() {
+	aoc.Register(13, Solver{})
+	aoc.RegisterGenerator(13, generate)
 }
 
-type Machine struct {
-	Prize   Vector
-	ButtonA Vector
-	ButtonB Vector
-}
+type Solver struct{}
+
+func (Solver) PartOne(input io.Reader) (int, error) {
+	machines, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
 
-func main() {
-	machines := parseInput(os.Stdin)
+	return cost(machines), nil
+}
 
-	start := time.Now()
-	fmt.Println("answer part one =", cost(machines))
-	fmt.Printf("part one took %+v\n", time.Since(start))
+func (Solver) PartTwo(input io.Reader) (int, error) {
+	machines, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
 
 	applyPartTwoOffset(machines)
-	start = time.Now()
-	fmt.Println("answer part two =", cost(machines))
-	fmt.Printf("part two took %+v", time.Since(start))
+	return cost(machines), nil
 }
 
 func cost(m []Machine) int {
@@ -70,9 +73,7 @@ func applyPartTwoOffset(machines []Machine) {
 func findPrize(m Machine) (int, bool) {
 	for a := 0; a < 100; a++ {
 		for b := 0; b < 100; b++ {
-			aLoc := Vector{X: m.ButtonA.X * a, Y: m.ButtonA.Y * a}
-			bLoc := Vector{X: m.ButtonB.X * b, Y: m.ButtonB.Y * b}
-			clawLoc := aLoc.Add(bLoc)
+			clawLoc := m.ButtonA.Scale(a).Add(m.ButtonB.Scale(b))
 
 			if clawLoc.X > m.Prize.X || clawLoc.Y > m.Prize.Y {
 				// break the innerloop if we've gone too far
@@ -110,65 +111,79 @@ func findPrizeCramersRule(m Machine) (int, bool) {
 		return -1, false
 	}
 
+	// buttons can't be pressed a negative number of times
+	if a < 0 || b < 0 {
+		return -1, false
+	}
+
 	return int(a*3 + b*1), true
 }
 
-func parseInput(input io.Reader) []Machine {
+func parseInput(input io.Reader) ([]Machine, error) {
 	var (
-		scanner  = bufio.NewScanner(input)
+		r        = parse.NewReader(input)
 		machines = []Machine{}
 	)
 
-	scanner.Split(scanBlocks)
-	for scanner.Scan() {
-		block := scanner.Text()
-
-		var m Machine
-		lines := strings.Split(block, "\n")
-		for _, line := range lines {
-			if line == "" {
-				continue
-			}
-
-			var x, y int
-			if _, err := fmt.Sscanf(line, "Button A: X+%d, Y+%d", &x, &y); err == nil {
-				m.ButtonA = Vector{X: x, Y: y}
-			}
-
-			if _, err := fmt.Sscanf(line, "Button B: X+%d, Y+%d", &x, &y); err == nil {
-				m.ButtonB = Vector{X: x, Y: y}
+	for block := range r.Blocks() {
+		var (
+			m          Machine
+			seen       = map[string]bool{}
+			lastLine   string
+			lastLineNo int
+		)
+
+		for _, l := range block.Lines {
+			var (
+				line   = l.Text
+				lineNo = l.Number
+				x, y   int
+				prefix = line[:strings.IndexByte(line+":", ':')]
+			)
+			lastLine, lastLineNo = line, lineNo
+
+			switch prefix {
+			case "Button A":
+				_, err := fmt.Sscanf(line, "Button A: X+%d, Y+%d", &x, &y)
+				if err != nil {
+					return nil, parse.Errorf(lineNo, 1, line, "invalid button: %w", err)
+				}
+				m.ButtonA = geom.Vector{X: x, Y: y}
+
+			case "Button B":
+				_, err := fmt.Sscanf(line, "Button B: X+%d, Y+%d", &x, &y)
+				if err != nil {
+					return nil, parse.Errorf(lineNo, 1, line, "invalid button: %w", err)
+				}
+				m.ButtonB = geom.Vector{X: x, Y: y}
+
+			case "Prize":
+				_, err := fmt.Sscanf(line, "Prize: X=%d, Y=%d", &x, &y)
+				if err != nil {
+					return nil, parse.Errorf(lineNo, 1, line, "invalid prize: %w", err)
+				}
+				m.Prize = geom.Vector{X: x, Y: y}
+
+			default:
+				return nil, parse.Errorf(lineNo, 1, line, "expected \"Button A\", \"Button B\" or \"Prize\"")
 			}
 
-			if _, err := fmt.Sscanf(line, "Prize: X=%d, Y=%d", &x, &y); err == nil {
-				m.Prize = Vector{X: x, Y: y}
+			if seen[prefix] {
+				return nil, parse.Errorf(lineNo, 1, line, "duplicate %s in machine", prefix)
 			}
+			seen[prefix] = true
 		}
-		machines = append(machines, m)
-	}
-
-	return machines
-}
 
-func dropCR(data []byte) []byte {
-	if len(data) > 0 && data[len(data)-1] == '\r' {
-		return data[0 : len(data)-1]
-	}
-	return data
-}
+		if len(seen) == 0 {
+			continue
+		}
 
-func scanBlocks(data []byte, atEOF bool) (advance int, token []byte, err error) {
-	if atEOF && len(data) == 0 {
-		return 0, nil, nil
-	}
+		if len(seen) != 3 {
+			return nil, parse.Errorf(lastLineNo, 1, lastLine, "incomplete machine, expected two buttons and a prize")
+		}
 
-	if i := strings.Index(string(data), "\n\n"); i >= 0 {
-		return i + 1, dropCR(data[0:i]), nil
+		machines = append(machines, m)
 	}
 
-	// If we're at EOF, we have a final, non-terminated line. Return it.
-	if atEOF {
-		return len(data), dropCR(data), nil
-	}
-	// Request more data.
-	return 0, nil, nil
+	return machines, r.Err()
 }
@@ -0,0 +1,124 @@
+package day13
+
+import (
+	"bytes"
+	"iter"
+	"math/rand/v2"
+	"testing"
+
+	"aoc24/aoc/aoctest"
+	"aoc24/difftest"
+	"aoc24/geom"
+)
+
+func FuzzParseInput(f *testing.F) {
+	aoctest.FuzzSeeds(f, generate)
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		parseInput(bytes.NewReader(input))
+	})
+}
+
+// exactSolution returns the number of presses of each button that move the
+// claw to the prize, if there's exactly one integer solution.
+func exactSolution(m Machine) (int, int, bool) {
+	det := m.ButtonA.X*m.ButtonB.Y - m.ButtonB.X*m.ButtonA.Y
+	if det == 0 {
+		return 0, 0, false
+	}
+
+	da := m.Prize.X*m.ButtonB.Y - m.ButtonB.X*m.Prize.Y
+	db := m.ButtonA.X*m.Prize.Y - m.Prize.X*m.ButtonA.Y
+	if da%det != 0 || db%det != 0 {
+		return 0, 0, false
+	}
+
+	return da / det, db / det, true
+}
+
+func TestDifferentialFindPrize(t *testing.T) {
+	type result struct {
+		cost  int
+		found bool
+	}
+
+	normalize := func(cost int, found bool) result {
+		if !found {
+			return result{}
+		}
+
+		return result{cost, true}
+	}
+
+	difftest.Case[Machine, result]{
+		Generate: func(rng *rand.Rand) Machine {
+			m := Machine{
+				ButtonA: geom.Vector{X: 1 + rng.IntN(99), Y: 1 + rng.IntN(99)},
+				ButtonB: geom.Vector{X: 1 + rng.IntN(99), Y: 1 + rng.IntN(99)},
+			}
+
+			// include a few presses that move away from the prize, which
+			// no real claw machine allows
+			m.Prize = m.ButtonA.Scale(rng.IntN(120) - 20).Add(m.ButtonB.Scale(rng.IntN(120) - 20))
+			if rng.IntN(2) == 0 {
+				m.Prize = m.Prize.Add(geom.Vector{X: rng.IntN(200) - 100, Y: rng.IntN(200) - 100})
+			}
+
+			return m
+		},
+		Shrink: func(m Machine) iter.Seq[Machine] {
+			return func(yield func(Machine) bool) {
+				// shrink the presses and buttons while keeping the prize
+				// reachable, fall back to shrinking the prize itself
+				a, b, found := exactSolution(m)
+				if !found {
+					a, b = 0, 0
+				}
+
+				variations := []*int{&a, &b, &m.ButtonA.X, &m.ButtonA.Y, &m.ButtonB.X, &m.ButtonB.Y}
+				if !found {
+					variations = []*int{&m.ButtonA.X, &m.ButtonA.Y, &m.ButtonB.X, &m.ButtonB.Y, &m.Prize.X, &m.Prize.Y}
+				}
+
+				for _, v := range variations {
+					original := *v
+					for shrunk := range difftest.ShrinkInt(original) {
+						*v = shrunk
+
+						candidate := m
+						if found {
+							candidate.Prize = m.ButtonA.Scale(a).Add(m.ButtonB.Scale(b))
+						}
+
+						if !yield(candidate) {
+							return
+						}
+					}
+
+					*v = original
+				}
+			}
+		},
+		// brute force only tries up to 99 presses of each button and only
+		// finds solutions moving towards the prize, Cramer's rule
+		// only applies to buttons that aren't parallel
+		Feasible: func(m Machine) bool {
+			if m.ButtonA.X <= 0 || m.ButtonA.Y <= 0 || m.ButtonB.X <= 0 || m.ButtonB.Y <= 0 || m.Prize.X < 0 || m.Prize.Y < 0 {
+				return false
+			}
+
+			if m.ButtonA.X*m.ButtonB.Y == m.ButtonB.X*m.ButtonA.Y {
+				return false
+			}
+
+			a, b, found := exactSolution(m)
+			return !found || a < 100 && b < 100
+		},
+		Naive: func(m Machine) result {
+			return normalize(findPrize(m))
+		},
+		Optimized: func(m Machine) result {
+			return normalize(findPrizeCramersRule(m))
+		},
+	}.Run(t, 10_000)
+}
@@ -0,0 +1,23 @@
+package day14
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+// generate produces size robots, spread over the 101 by 103 tiles of the
+// bathroom and moving at up to 100 tiles per second in each direction.
+func generate(rng *rand.Rand, size int) []byte {
+	var b strings.Builder
+	for range size {
+		fmt.Fprintf(&b, "p=%d,%d v=%d,%d\n",
+			rng.IntN(RoomBathroom.Width),
+			rng.IntN(RoomBathroom.Height),
+			rng.IntN(201)-100,
+			rng.IntN(201)-100,
+		)
+	}
+
+	return []byte(b.String())
+}
@@ -1,20 +1,18 @@
-package main
+package day14
 
 import (
-	"bufio"
 	"fmt"
 	"io"
-	"os"
-	"time"
-)
+	"strings"
 
-type Vector struct {
-	X, Y int
-}
+	"aoc24/aoc"
+	"aoc24/geom"
+	"aoc24/parse"
+)
 
 type Robot struct {
-	Pos Vector
-	Vel Vector
+	Pos geom.Vector
+	Vel geom.Vector
 }
 
 type Quadrant int
@@ -27,24 +25,35 @@ const (
 	QuadrantBottomRight
 )
 
-const (
-	mapWidth  = 101 // 11 for example input
-	mapHeight = 103 // 7 for example input
-	iters     = 100
+const iters = 100
+
+// Room is the size of the room the robots move around in.
+type Room struct {
+	Width, Height int
+}
+
+var (
+	// RoomExample is the room of the example input.
+	RoomExample = Room{Width: 11, Height: 7}
+
+	// RoomBathroom is the room of the real input, which the solver assumes.
+	RoomBathroom = Room{Width: 101, Height: 103}
 )
 
-func determineQuadrant(x, y int) Quadrant {
+func (room Room) quadrant(x, y int) Quadrant {
+	midX, midY := room.Width/2, room.Height/2
+
 	switch {
-	case x < mapWidth/2 && y < mapHeight/2:
+	case x < midX && y < midY:
 		return QuadrantTopLeft
 
-	case x > mapWidth/2 && y < mapHeight/2:
+	case x > midX && y < midY:
 		return QuadrantTopRight
 
-	case x < mapWidth/2 && y > mapHeight/2:
+	case x < midX && y > midY:
 		return QuadrantBottomLeft
 
-	case x > mapWidth/2 && y > mapHeight/2:
+	case x > midX && y > midY:
 		return QuadrantBottomRight
 	}
 
@@ -54,29 +63,49 @@ func determineQuadrant(x, y int) Quadrant {
 	return QuadrantNone
 }
 
-func main() {
-	robots := parseInput(os.Stdin)
+// wrap teleports a position that went off the map back onto it,
+// returning the resulting X and Y coordinate.
+func (room Room) wrap(pos geom.Vector) (int, int) {
+	x := (pos.X%room.Width + room.Width) % room.Width
+	y := (pos.Y%room.Height + room.Height) % room.Height
+	return x, y
+}
+
+func init() {
+	aoc.Register(14, Solver{})
+	aoc.RegisterGenerator(14, generate)
+}
 
-	start := time.Now()
-	fmt.Println("answer part one =", partOne(robots))
-	fmt.Printf("part one took %+v\n", time.Since(start))
+type Solver struct{}
 
-	start = time.Now()
-	fmt.Println("answer part two =", partTwo(robots))
-	fmt.Printf("part two took %+v", time.Since(start))
+func (Solver) PartOne(input io.Reader) (int, error) {
+	robots, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
+
+	return partOne(robots, RoomBathroom), nil
+}
+
+func (Solver) PartTwo(input io.Reader) (int, error) {
+	robots, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
+
+	return partTwo(robots, RoomBathroom), nil
 }
 
-func partOne(robots []Robot) int {
+func partOne(robots []Robot, room Room) int {
 	countPerQuadrant := map[Quadrant]int{}
 
 	for _, r := range robots {
 		// find the final X and Y after N iterations in one go using modulo
-		x := ((r.Pos.X+r.Vel.X*iters)%mapWidth + mapWidth) % mapWidth
-		y := ((r.Pos.Y+r.Vel.Y*iters)%mapHeight + mapHeight) % mapHeight
+		x, y := room.wrap(r.Pos.Add(r.Vel.Scale(iters)))
 
 		// find to which quadrant the robot belongs based on its final X and Y
 		// coordinate and increment the counter that belongs to the quadrant.
-		q := determineQuadrant(x, y)
+		q := room.quadrant(x, y)
 		countPerQuadrant[q] += 1
 	}
 
@@ -91,26 +120,27 @@ func partOne(robots []Robot) int {
 	return total
 }
 
-func partTwo(robots []Robot) int {
-	const (
-		MAX_FRAMES = 20_000
-		SEQ_MIN    = 10
-	)
+func partTwo(robots []Robot, room Room) int {
+	const SEQ_MIN = 10
+
+	// every robot is back where it started after Width frames horizontally
+	// and Height frames vertically, so the arrangements repeat after
+	// Width*Height frames at the latest.
+	frames := room.Width * room.Height
 
-	for frame := range MAX_FRAMES {
+	for frame := range frames {
 		bathroom := map[[2]int]struct{}{}
 		for _, r := range robots {
 			// find the final X and Y after N iterations in one go using modulo
-			x := ((r.Pos.X+r.Vel.X*frame)%mapWidth + mapWidth) % mapWidth
-			y := ((r.Pos.Y+r.Vel.Y*frame)%mapHeight + mapHeight) % mapHeight
+			x, y := room.wrap(r.Pos.Add(r.Vel.Scale(frame)))
 
 			bathroom[[2]int{x, y}] = struct{}{}
 		}
 
 		// detecting SEQ_MIN sequential robots on the X axis.
 		var seq = 0
-		for y := 0; y < mapHeight; y++ {
-			for x := 0; x < mapWidth; x++ {
+		for y := 0; y < room.Height; y++ {
+			for x := 0; x < room.Width; x++ {
 				k := [2]int{x, y}
 				if _, found := bathroom[k]; found {
 					seq++
@@ -130,27 +160,32 @@ func partTwo(robots []Robot) int {
 	return -1
 }
 
-func parseInput(input io.Reader) []Robot {
+func parseInput(input io.Reader) ([]Robot, error) {
 	var (
-		scanner = bufio.NewScanner(input)
-		robots  = []Robot{}
+		r      = parse.NewReader(input)
+		robots = []Robot{}
 	)
 
-	for scanner.Scan() {
-		line := scanner.Text()
-
+	for line := range r.Lines() {
 		var m Robot
-		if line == "" {
+		if line.Text == "" {
 			continue
 		}
 
-		_, err := fmt.Sscanf(line, "p=%d,%d v=%d,%d", &m.Pos.X, &m.Pos.Y, &m.Vel.X, &m.Vel.Y)
+		sr := strings.NewReader(line.Text)
+		_, err := fmt.Fscanf(sr, "p=%d,%d v=%d,%d", &m.Pos.X, &m.Pos.Y, &m.Vel.X, &m.Vel.Y)
 		if err != nil {
-			panic(fmt.Errorf("unable to parse line '%s': %w", line, err))
+			return nil, parse.Errorf(line.Number, 1, line.Text, "expected a robot in the form of \"p=X,Y v=X,Y\": %w", err)
+		}
+
+		// Fscanf stops after the velocity, anything but spaces after it is
+		// garbage
+		if rest := strings.TrimLeft(line.Text[len(line.Text)-sr.Len():], " \t"); rest != "" {
+			return nil, parse.Errorf(line.Number, len(line.Text)-len(rest)+1, rest, "unexpected text after the robot")
 		}
 
 		robots = append(robots, m)
 	}
 
-	return robots
+	return robots, r.Err()
 }
@@ -0,0 +1,57 @@
+package day14
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"aoc24/aoc/aoctest"
+	"aoc24/geom"
+	"aoc24/parse"
+)
+
+func assert(t *testing.T, a, b any, msg string) {
+	t.Helper()
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("assertion failed: %s. Expected %+v, got %+v", msg, b, a)
+	}
+}
+
+func TestExample(t *testing.T) {
+	f, err := os.Open("example.txt")
+	assert(t, err, nil, "unable to open example")
+	defer f.Close()
+
+	robots, err := parseInput(f)
+	assert(t, err, nil, "unexpected error")
+
+	// the example takes place in a smaller room than the real input
+	assert(t, partOne(robots, RoomExample), 12, "incorrect safety factor")
+	assert(t, partTwo(robots, RoomExample), -1, "the example has no tree")
+}
+
+func TestParseInput(t *testing.T) {
+	robots, err := parseInput(strings.NewReader("p=0,4 v=3,-3\n\np=6,3 v=-1,-3  \n"))
+	assert(t, err, nil, "unexpected error")
+	assert(t, robots, []Robot{
+		{Pos: geom.Vector{X: 0, Y: 4}, Vel: geom.Vector{X: 3, Y: -3}},
+		{Pos: geom.Vector{X: 6, Y: 3}, Vel: geom.Vector{X: -1, Y: -3}},
+	}, "incorrect robots")
+
+	_, err = parseInput(strings.NewReader("p=0,4 v=3,-3\np=6,3 v=-1,-3 p=1,1\n"))
+	var perr *parse.Error
+	assert(t, errors.As(err, &perr), true, "trailing text should be rejected")
+	assert(t, [2]int{perr.Line, perr.Column}, [2]int{2, 15}, "error should point at the trailing text")
+	assert(t, perr.Text, "p=1,1", "incorrect offending text")
+}
+
+func FuzzParseInput(f *testing.F) {
+	aoctest.FuzzSeeds(f, generate)
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		parseInput(bytes.NewReader(input))
+	})
+}
@@ -0,0 +1,52 @@
+package day15
+
+import (
+	"math/rand/v2"
+	"strings"
+
+	"aoc24/geom"
+	"aoc24/grid"
+)
+
+// generate produces a warehouse of size by size tiles, walled in and with a
+// few walls and plenty of boxes inside, followed by 10*size*size moves of the
+// robot.
+func generate(rng *rand.Rand, size int) []byte {
+	// leave room for the robot within the outer walls
+	size = max(size, 3)
+
+	g := grid.New[byte](size, size)
+	for pos := range g.All() {
+		tile := byte('.')
+		switch n := rng.IntN(20); {
+		case pos.X == 0 || pos.Y == 0 || pos.X == size-1 || pos.Y == size-1:
+			tile = '#'
+
+		case n == 0:
+			tile = '#'
+
+		case n < 6:
+			tile = 'O'
+		}
+
+		g.Set(pos, tile)
+	}
+
+	g.Set(geom.Vector{X: 1 + rng.IntN(size-2), Y: 1 + rng.IntN(size-2)}, '@')
+
+	const moves = "^>v<"
+
+	var b strings.Builder
+	b.WriteString(g.String())
+	b.WriteString("\n\n")
+	for idx := range 10 * size * size {
+		if idx > 0 && idx%70 == 0 {
+			b.WriteByte('\n')
+		}
+
+		b.WriteByte(moves[rng.IntN(len(moves))])
+	}
+
+	b.WriteByte('\n')
+	return []byte(b.String())
+}
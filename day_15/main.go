@@ -1,48 +1,31 @@
-package main
+package day15
 
 import (
-	"bufio"
 	"fmt"
 	"io"
 	"maps"
-	"os"
 	"slices"
-	"time"
-)
 
-var (
-	DirectionUp    = Vector{X: 0, Y: -1}
-	DirectionRight = Vector{X: +1, Y: 0}
-	DirectionDown  = Vector{X: 0, Y: +1}
-	DirectionLeft  = Vector{X: -1, Y: 0}
+	"aoc24/aoc"
+	"aoc24/geom"
+	"aoc24/parse"
 )
 
-func vectorForInstruction(instr Instruction) Vector {
+func vectorForInstruction(instr Instruction) geom.Vector {
 	switch instr {
 	case InstructionUp:
-		return DirectionUp
+		return geom.DirectionNorth
 	case InstructionRight:
-		return DirectionRight
+		return geom.DirectionEast
 	case InstructionDown:
-		return DirectionDown
+		return geom.DirectionSouth
 	case InstructionLeft:
-		return DirectionLeft
+		return geom.DirectionWest
 	}
 
 	panic(fmt.Errorf("invalid instruction: %s", string(instr)))
 }
 
-type Vector struct {
-	X, Y int
-}
-
-func (v Vector) Add(v2 Vector) Vector {
-	return Vector{
-		X: v.X + v2.X,
-		Y: v.Y + v2.Y,
-	}
-}
-
 type ObjTyp int
 
 const (
@@ -53,11 +36,11 @@ const (
 type Object struct {
 	width  int
 	height int
-	pos    Vector
+	pos    geom.Vector
 	typ    ObjTyp
 }
 
-func (o *Object) MoveInDirection(direction Vector) {
+func (o *Object) MoveInDirection(direction geom.Vector) {
 	o.pos = o.pos.Add(direction)
 }
 
@@ -122,11 +105,11 @@ func (w World) Print(r *Robot) {
 	fmt.Println()
 }
 
-func (w World) InBounds(pos Vector) bool {
+func (w World) InBounds(pos geom.Vector) bool {
 	return pos.X >= 0 && pos.Y >= 0 && pos.X <= w.width && pos.Y <= w.height
 }
 
-func (w World) FindCollisions(obj1 *Object, direction Vector, ignoring []*Object) []*Object {
+func (w World) FindCollisions(obj1 *Object, direction geom.Vector, ignoring []*Object) []*Object {
 	var collisions []*Object
 	for _, obj2 := range w.objects {
 		// no comparison with self
@@ -163,7 +146,7 @@ func (w World) FindCollisions(obj1 *Object, direction Vector, ignoring []*Object
 // CanMove returns true if in the obj van move in direction
 // either because it find an empty space or the objects it collides with
 // are in turn movable too.
-func (w World) CanMove(obj *Object, direction Vector) bool {
+func (w World) CanMove(obj *Object, direction geom.Vector) bool {
 	collisions := w.FindCollisions(obj, direction, []*Object{})
 
 	for _, col := range collisions {
@@ -205,14 +188,14 @@ func (w *World) FindObjectsOfType(typ ObjTyp) []*Object {
 }
 
 type Robot struct {
-	Vector
+	geom.Vector
 }
 
 func (r Robot) Clone() Robot {
 	return Robot{r.Vector}
 }
 
-func (r *Robot) Move(to Vector) {
+func (r *Robot) Move(to geom.Vector) {
 	r.Vector = to
 }
 
@@ -232,20 +215,30 @@ const (
 	InstructionLeft  = Instruction('<')
 )
 
-func main() {
-	world, robot, instructions := parseInput(os.Stdin)
+func init() {
+	aoc.Register(15, Solver{})
+	aoc.RegisterGenerator(15, generate)
+}
 
-	world2, robot2 := world.Clone(), robot.Clone()
+type Solver struct{}
 
-	start := time.Now()
-	fmt.Println("answer part one =", getAnswer(&world, &robot, instructions))
-	fmt.Printf("part one took %+v\n", time.Since(start))
+func (Solver) PartOne(input io.Reader) (int, error) {
+	world, robot, instructions, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
+
+	return getAnswer(&world, &robot, instructions), nil
+}
 
-	applyWidening(&world2, &robot2)
+func (Solver) PartTwo(input io.Reader) (int, error) {
+	world, robot, instructions, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
 
-	start = time.Now()
-	fmt.Println("answer part two =", getAnswer(&world2, &robot2, instructions))
-	fmt.Printf("part two took %+v", time.Since(start))
+	applyWidening(&world, &robot)
+	return getAnswer(&world, &robot, instructions), nil
 }
 
 func applyWidening(w *World, r *Robot) {
@@ -301,18 +294,19 @@ const (
 	ParseTypeInstructions
 )
 
-func parseInput(input io.Reader) (World, Robot, []Instruction) {
+func parseInput(input io.Reader) (World, Robot, []Instruction, error) {
 	var (
-		scanner      = bufio.NewScanner(input)
+		r            = parse.NewReader(input)
 		world        World
 		robot        Robot
 		instructions []Instruction
 		parseType    = ParseTypeMap
 		lineNo       int
+		robots       int
 	)
 
-	for scanner.Scan() {
-		line := scanner.Text()
+	for l := range r.Lines() {
+		line := l.Text
 
 		// found the double linebreak,
 		// now parsing instructions
@@ -332,7 +326,7 @@ func parseInput(input io.Reader) (World, Robot, []Instruction) {
 						width:  1,
 						height: 1,
 						typ:    ObjTypeWall,
-						pos: Vector{
+						pos: geom.Vector{
 							X: idx,
 							Y: lineNo,
 						},
@@ -343,7 +337,7 @@ func parseInput(input io.Reader) (World, Robot, []Instruction) {
 						width:  1,
 						height: 1,
 						typ:    ObjTypeBox,
-						pos: Vector{
+						pos: geom.Vector{
 							X: idx,
 							Y: lineNo,
 						},
@@ -352,6 +346,13 @@ func parseInput(input io.Reader) (World, Robot, []Instruction) {
 				case '@':
 					robot.X = idx
 					robot.Y = lineNo
+					robots++
+
+				case '.':
+					// open space, nothing to place
+
+				default:
+					return World{}, Robot{}, nil, parse.Errorf(l.Number, idx+1, string(c), "invalid tile")
 				}
 
 				world.width = idx + 1
@@ -365,13 +366,27 @@ func parseInput(input io.Reader) (World, Robot, []Instruction) {
 
 		// parsing of the instructions is straight forward
 		if parseType == ParseTypeInstructions {
-			for _, c := range line {
-				instructions = append(instructions, Instruction(c))
+			for idx, c := range line {
+				switch instr := Instruction(c); instr {
+				case InstructionUp, InstructionRight, InstructionDown, InstructionLeft:
+					instructions = append(instructions, instr)
+
+				default:
+					return World{}, Robot{}, nil, parse.Errorf(l.Number, idx+1, string(c), "invalid instruction")
+				}
 			}
 		}
 	}
 
-	return world, robot, instructions
+	if err := r.Err(); err != nil {
+		return World{}, Robot{}, nil, err
+	}
+
+	if robots != 1 {
+		return World{}, Robot{}, nil, fmt.Errorf("expected a single robot, found %d", robots)
+	}
+
+	return world, robot, instructions, nil
 }
 
 func unique[T comparable](input []T) []T {
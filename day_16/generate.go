@@ -0,0 +1,50 @@
+package day16
+
+import (
+	"math/rand/v2"
+
+	"aoc24/geom"
+	"aoc24/grid"
+)
+
+// generate produces a maze of size by size tiles with the start in the bottom
+// left and the end in the top right corner. Walls are placed at random, after
+// which a path is carved from start to end so the end can always be reached.
+func generate(rng *rand.Rand, size int) []byte {
+	// leave room for the start and end within the outer walls
+	size = max(size, 4)
+
+	g := grid.New[TileType](size, size)
+	for pos := range g.All() {
+		tile := TileTypeOpen
+		if pos.X == 0 || pos.Y == 0 || pos.X == size-1 || pos.Y == size-1 || rng.IntN(3) == 0 {
+			tile = TileTypeWall
+		}
+
+		g.Set(pos, tile)
+	}
+
+	var (
+		start = geom.Vector{X: 1, Y: size - 2}
+		end   = geom.Vector{X: size - 2, Y: 1}
+	)
+
+	for pos := start; pos != end; {
+		g.Set(pos, TileTypeOpen)
+		if pos.X == end.X || (pos.Y != end.Y && rng.IntN(2) == 0) {
+			pos = pos.Add(geom.DirectionNorth)
+			continue
+		}
+
+		pos = pos.Add(geom.DirectionEast)
+	}
+
+	g.Set(start, TileTypeStart)
+	g.Set(end, TileTypeEnd)
+
+	rendered := g.Render(func(_ geom.Vector, tile TileType) string {
+		return string(tile)
+	})
+
+	return []byte(rendered + "\n")
+}
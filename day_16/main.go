@@ -1,32 +1,14 @@
-package main
+package day16
 
 import (
-	"bufio"
 	"fmt"
 	"io"
-	"math"
-	"os"
 	"strings"
-	"time"
-)
-
-type Vector struct {
-	X, Y int
-}
 
-func (v Vector) Add(v2 Vector) Vector {
-	return Vector{
-		X: v.X + v2.X,
-		Y: v.Y + v2.Y,
-	}
-}
-
-func (v Vector) Sub(v2 Vector) Vector {
-	return Vector{
-		X: v.X - v2.X,
-		Y: v.Y - v2.Y,
-	}
-}
+	"aoc24/aoc"
+	"aoc24/geom"
+	"aoc24/grid"
+)
 
 type TileType rune
 
@@ -37,78 +19,52 @@ const (
 	TileTypeEnd   = TileType('E')
 )
 
-var (
-	DirectionNorth = Vector{X: 0, Y: -1}
-	DirectionEast  = Vector{X: 1, Y: 0}
-	DirectionSouth = Vector{X: 0, Y: 1}
-	DirectionWest  = Vector{X: -1, Y: 0}
-)
-
 type Grid struct {
-	width  int
-	height int
-	tiles  []TileType
+	grid.Grid[TileType]
 }
 
 type Reindeer struct {
-	pos Vector
-	dir Vector
+	pos geom.Vector
+	dir geom.Vector
 }
 
-func (g *Grid) Print(start, end, reindeer Vector) {
-	for y := 0; y < g.height; y++ {
-		for x := 0; x < g.width; x++ {
-			pos := Vector{X: x, Y: y}
-
-			if pos == reindeer {
-				fmt.Print("@")
-				continue
-			}
+func (g *Grid) Print(start, end, reindeer geom.Vector) {
+	fmt.Println(g.Render(func(pos geom.Vector, tile TileType) string {
+		switch pos {
+		case reindeer:
+			return "@"
 
-			if pos == start {
-				fmt.Print("S")
-				continue
-			}
+		case start:
+			return "S"
 
-			if pos == end {
-				fmt.Print("E")
-				continue
-			}
-
-			tile := g.TileAt(pos)
-			fmt.Printf("%s", string(tile))
+		case end:
+			return "E"
 		}
-		fmt.Println()
-	}
-}
 
-func (g *Grid) PrintWithPath(start, end Vector, path []Vector) {
-	res := make([]rune, g.width*g.height)
+		return string(tile)
+	}))
+}
 
-	for y := 0; y < g.height; y++ {
-		for x := 0; x < g.width; x++ {
-			pos := Vector{X: x, Y: y}
-			idx := y*g.width + x
+func (g *Grid) PrintWithPath(start, end geom.Vector, path []geom.Vector) {
+	res := make([]rune, 0, g.Width()*g.Height())
 
-			if pos == start {
-				res[idx] = 'S'
-				continue
-			}
+	for pos, tile := range g.All() {
+		switch pos {
+		case start:
+			res = append(res, 'S')
 
-			if pos == end {
-				res[idx] = 'E'
-				continue
-			}
+		case end:
+			res = append(res, 'E')
 
-			tile := g.TileAt(pos)
-			res[idx] = rune(tile)
+		default:
+			res = append(res, rune(tile))
 		}
 	}
 
-	direction := DirectionEast
+	direction := geom.DirectionEast
 	prevPos := start
 	for _, pos := range path {
-		idx := pos.Y*g.width + pos.X
+		idx := pos.Y*g.Width() + pos.X
 
 		if pos != prevPos {
 			direction = pos.Sub(prevPos)
@@ -116,16 +72,16 @@ func (g *Grid) PrintWithPath(start, end Vector, path []Vector) {
 
 		prevPos = pos
 		switch direction {
-		case DirectionNorth:
+		case geom.DirectionNorth:
 			res[idx] = '^'
 
-		case DirectionWest:
+		case geom.DirectionWest:
 			res[idx] = '<'
 
-		case DirectionSouth:
+		case geom.DirectionSouth:
 			res[idx] = 'v'
 
-		case DirectionEast:
+		case geom.DirectionEast:
 			res[idx] = '>'
 		}
 		continue
@@ -133,7 +89,7 @@ func (g *Grid) PrintWithPath(start, end Vector, path []Vector) {
 
 	var b strings.Builder
 	for idx, cell := range res {
-		if idx > 0 && idx%g.width == 0 {
+		if idx > 0 && idx%g.Width() == 0 {
 			b.WriteRune('\n')
 		}
 
@@ -148,23 +104,10 @@ func (g *Grid) PrintWithPath(start, end Vector, path []Vector) {
 	fmt.Print(b.String())
 }
 
-func (g *Grid) TileAt(pos Vector) TileType {
-	idx := g.width*pos.Y + pos.X
-	return g.tiles[idx]
-}
-
-func (g *Grid) WalkableTilesSurrounding(pos Vector) []Vector {
-	var walkable []Vector
-	surroundings := []Vector{
-		DirectionNorth,
-		DirectionEast,
-		DirectionSouth,
-		DirectionWest,
-	}
-
-	for _, dir := range surroundings {
-		newPos := pos.Add(dir)
-		if g.TileAt(newPos) == TileTypeOpen {
+func (g *Grid) WalkableTilesSurrounding(pos geom.Vector) []geom.Vector {
+	var walkable []geom.Vector
+	for newPos := range g.Neighbours4(pos) {
+		if g.At(newPos) == TileTypeOpen {
 			walkable = append(walkable, newPos)
 		}
 	}
@@ -172,21 +115,33 @@ func (g *Grid) WalkableTilesSurrounding(pos Vector) []Vector {
 	return walkable
 }
 
-func main() {
-	grid, start, end := parseInput(os.Stdin)
+func init() {
+	aoc.Register(16, Solver{})
+	aoc.RegisterGenerator(16, generate)
+}
+
+type Solver struct{}
+
+func (Solver) PartOne(input io.Reader) (int, error) {
+	g, start, end, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
+
+	return partOne(g, start, end), nil
+}
 
-	startTime := time.Now()
-	fmt.Println("part one = ", partOne(grid, start, end))
-	fmt.Println("part one took = ", time.Since(startTime))
+func (Solver) PartTwo(input io.Reader) (int, error) {
+	return 0, aoc.ErrNotImplemented
 }
 
-func partOne(grid Grid, start, end Vector) int {
+func partOne(g Grid, start, end geom.Vector) int {
 	pf := NewPathFinder(PathFinderOpts{
-		NeighboursFn: grid.WalkableTilesSurrounding,
-		HeuristicFn: func(l Vector) int {
-			return manhattan(l, end)
+		NeighboursFn: g.WalkableTilesSurrounding,
+		HeuristicFn: func(l geom.Vector) int {
+			return l.Manhattan(end)
 		},
-		ReachedFinishFn: func(l Vector) bool {
+		ReachedFinishFn: func(l geom.Vector) bool {
 			return l == end
 		},
 	})
@@ -195,45 +150,37 @@ func partOne(grid Grid, start, end Vector) int {
 	return cost
 }
 
-func manhattan(a, b Vector) int {
-	return abs(a.X-b.X) + abs(a.Y-b.Y)
-}
-
-func abs(a int) int {
-	return int(math.Abs(float64(a)))
-}
-
-func parseInput(input io.Reader) (Grid, Vector, Vector) {
+func parseInput(input io.Reader) (Grid, geom.Vector, geom.Vector, error) {
 	var (
-		scanner = bufio.NewScanner(input)
-		grid    Grid
-		start   Vector
-		end     Vector
+		start, end   geom.Vector
+		starts, ends int
 	)
 
-	for scanner.Scan() {
-		line := scanner.Text()
-		grid.width = 0
-
-		for _, c := range line {
-			switch TileType(c) {
-			case TileTypeWall, TileTypeOpen:
-				grid.tiles = append(grid.tiles, TileType(c))
+	g, err := grid.Parse(input, func(pos geom.Vector, c byte) (TileType, error) {
+		switch TileType(c) {
+		case TileTypeWall, TileTypeOpen:
+			return TileType(c), nil
 
-			case TileTypeStart:
-				grid.tiles = append(grid.tiles, TileTypeOpen)
-				start = Vector{X: grid.width, Y: grid.height}
+		case TileTypeStart:
+			start = pos
+			starts++
+			return TileTypeOpen, nil
 
-			case TileTypeEnd:
-				grid.tiles = append(grid.tiles, TileTypeOpen)
-				end = Vector{X: grid.width, Y: grid.height}
-			}
-
-			grid.width++
+		case TileTypeEnd:
+			end = pos
+			ends++
+			return TileTypeOpen, nil
 		}
 
-		grid.height++
+		return 0, fmt.Errorf("invalid tile")
+	})
+	if err != nil {
+		return Grid{}, start, end, err
+	}
+
+	if starts != 1 || ends != 1 {
+		return Grid{}, start, end, fmt.Errorf("expected a single start and end tile, found %d and %d", starts, ends)
 	}
 
-	return grid, start, end
+	return Grid{g}, start, end, nil
 }
@@ -1,14 +1,16 @@
-package main
+package day16
 
 import (
 	"math"
 	"slices"
 
 	"github.com/tmw/go-prioqueue"
+
+	"aoc24/geom"
 )
 
 type Candidate struct {
-	loc Vector
+	loc geom.Vector
 
 	// total cost of this candidate. This contains
 	// distance from the start, heuristic to finish and optional penalty
@@ -23,39 +25,39 @@ type Candidate struct {
 	via *Candidate
 
 	// what direction did we come from when we landed here
-	dir Vector
+	dir geom.Vector
 }
 
 type PathFinder struct {
 	PathFinderOpts
-	visited map[Vector]Candidate
-	queue   prioqueue.PrioQueue[Candidate, Vector]
+	visited map[geom.Vector]Candidate
+	queue   prioqueue.PrioQueue[Candidate, geom.Vector]
 }
 
 type PathFinderOpts struct {
-	NeighboursFn    func(l Vector) []Vector
-	HeuristicFn     func(l Vector) int
-	ReachedFinishFn func(l Vector) bool
+	NeighboursFn    func(l geom.Vector) []geom.Vector
+	HeuristicFn     func(l geom.Vector) int
+	ReachedFinishFn func(l geom.Vector) bool
 }
 
 func NewPathFinder(opts PathFinderOpts) PathFinder {
 	return PathFinder{
 		PathFinderOpts: opts,
-		visited:        make(map[Vector]Candidate),
+		visited:        make(map[geom.Vector]Candidate),
 		queue: prioqueue.NewPrioQueue(
 			compareCandidate,
 			hashCandidate,
 		),
 	}
 }
-func (p *PathFinder) Path(start Vector) (int, []Vector) {
+func (p *PathFinder) Path(start geom.Vector) (int, []geom.Vector) {
 	initialCost := p.HeuristicFn(start)
 	p.queue.Push(Candidate{
 		loc:  start,
 		cost: initialCost,
 		dist: 0,
 		via:  nil,
-		dir:  DirectionEast,
+		dir:  geom.DirectionEast,
 	})
 
 	for {
@@ -113,11 +115,11 @@ func (p *PathFinder) Path(start Vector) (int, []Vector) {
 		p.visited[c.loc] = c
 	}
 
-	return math.MaxInt, []Vector{}
+	return math.MaxInt, []geom.Vector{}
 }
 
-func backtrack(c Candidate) []Vector {
-	var path []Vector
+func backtrack(c Candidate) []geom.Vector {
+	var path []geom.Vector
 
 	for {
 		path = append(path, c.loc)
@@ -132,7 +134,7 @@ func backtrack(c Candidate) []Vector {
 	return path
 }
 
-func hashCandidate(c Candidate) Vector {
+func hashCandidate(c Candidate) geom.Vector {
 	return c.loc
 }
 
@@ -0,0 +1,57 @@
+package days
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"aoc24/aoc"
+)
+
+// BenchmarkSolvers benchmarks both parts of every day against the inputs
+// listed in the answers files, as well as the personal input of the day
+// when present.
+func BenchmarkSolvers(b *testing.B) {
+	expectations, err := aoc.ReadExpectations(filepath.Join(root, "answers.txt"))
+	if err != nil {
+		b.Fatalf("unable to read answers: %v", err)
+	}
+
+	inputs := map[int][]string{}
+	for _, e := range expectations {
+		inputs[e.Day] = append(inputs[e.Day], e.Input)
+	}
+
+	for _, day := range aoc.Days() {
+		solver, _ := aoc.Lookup(day)
+		paths := append(inputs[day], fmt.Sprintf("day_%02d/input.txt", day))
+
+		for _, path := range paths {
+			input, err := os.ReadFile(filepath.Join(root, path))
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+
+			if err != nil {
+				b.Fatalf("unable to read input: %v", err)
+			}
+
+			for _, part := range aoc.Parts {
+				name := fmt.Sprintf("day=%d/part=%d/input=%s", day, part, filepath.Base(path))
+				b.Run(name, func(b *testing.B) {
+					if _, err := aoc.Solve(solver, part, bytes.NewReader(input)); err != nil {
+						b.Skipf("not benchmarking: %v", err)
+					}
+
+					b.ReportAllocs()
+					for range b.N {
+						aoc.Solve(solver, part, bytes.NewReader(input))
+					}
+				})
+			}
+		}
+	}
+}
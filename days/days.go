@@ -0,0 +1,21 @@
+// Package days imports every day, registering their solvers with package aoc.
+package days
+
+import (
+	_ "aoc24/day_01"
+	_ "aoc24/day_02"
+	_ "aoc24/day_03"
+	_ "aoc24/day_04"
+	_ "aoc24/day_05"
+	_ "aoc24/day_06"
+	_ "aoc24/day_07"
+	_ "aoc24/day_08"
+	_ "aoc24/day_09"
+	_ "aoc24/day_10"
+	_ "aoc24/day_11"
+	_ "aoc24/day_12"
+	_ "aoc24/day_13"
+	_ "aoc24/day_14"
+	_ "aoc24/day_15"
+	_ "aoc24/day_16"
+)
@@ -0,0 +1,118 @@
+package days
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"aoc24/aoc"
+)
+
+// generatedSizes lists the input sizes to benchmark each day at, see the
+// generator of each day for what the size means. Days that aren't listed use
+// defaultGeneratedSizes.
+var generatedSizes = map[int][]int{
+	1:  {100, 1000, 10000},
+	2:  {100, 1000, 10000},
+	3:  {1000, 10000, 100000},
+	4:  {10, 100, 1000},
+	5:  {10, 100, 1000},
+	6:  {10, 50, 100},
+	7:  {10, 100, 1000},
+	8:  {10, 100, 250},
+	9:  {100, 1000, 10000},
+	10: {10, 100, 1000},
+	11: {10, 100, 1000},
+	12: {10, 100, 200},
+	13: {10, 100, 1000},
+	14: {10, 100, 500},
+	15: {10, 25, 50},
+	16: {10, 50, 100},
+}
+
+var defaultGeneratedSizes = []int{10, 100, 1000}
+
+func sizes(day int) []int {
+	if sizes, found := generatedSizes[day]; found {
+		return sizes
+	}
+
+	return defaultGeneratedSizes
+}
+
+func TestGenerated(t *testing.T) {
+	for _, day := range aoc.Days() {
+		if _, found := aoc.LookupGenerator(day); !found {
+			t.Errorf("day %d has no input generator", day)
+		}
+	}
+
+	for _, day := range aoc.GeneratorDays() {
+		t.Run(fmt.Sprintf("day %d", day), func(t *testing.T) {
+			solver, found := aoc.Lookup(day)
+			if !found {
+				t.Fatalf("day %d has a generator but no solver", day)
+			}
+
+			size := sizes(day)[0]
+			input, err := aoc.Generate(day, 1, size)
+			if err != nil {
+				t.Fatalf("unable to generate input: %v", err)
+			}
+
+			again, _ := aoc.Generate(day, 1, size)
+			if !bytes.Equal(input, again) {
+				t.Fatalf("generator isn't deterministic")
+			}
+
+			other, _ := aoc.Generate(day, 2, size)
+			if bytes.Equal(input, other) {
+				t.Fatalf("generator ignores the seed")
+			}
+
+			for _, part := range aoc.Parts {
+				t.Run(part.String(), func(t *testing.T) {
+					// searches all 10403 possible arrangements of the robots
+					// before giving up on finding the tree
+					if day == 14 && part == aoc.PartTwo && testing.Short() {
+						t.Skip("slow, skipped in short mode")
+					}
+
+					_, err := aoc.Solve(solver, part, bytes.NewReader(input))
+					if err != nil && !errors.Is(err, aoc.ErrNotImplemented) {
+						t.Fatalf("unexpected error solving generated input: %v\n%s", err, input)
+					}
+				})
+			}
+		})
+	}
+}
+
+// BenchmarkGenerated benchmarks both parts of every day against generated
+// inputs of increasing size, showing how the solvers scale.
+func BenchmarkGenerated(b *testing.B) {
+	for _, day := range aoc.GeneratorDays() {
+		solver, _ := aoc.Lookup(day)
+		for _, size := range sizes(day) {
+			input, err := aoc.Generate(day, 1, size)
+			if err != nil {
+				b.Fatalf("unable to generate input: %v", err)
+			}
+
+			for _, part := range aoc.Parts {
+				name := fmt.Sprintf("day=%d/part=%d/size=%d", day, part, size)
+				b.Run(name, func(b *testing.B) {
+					if _, err := aoc.Solve(solver, part, bytes.NewReader(input)); err != nil {
+						b.Skipf("not benchmarking: %v", err)
+					}
+
+					b.ReportAllocs()
+					for range b.N {
+						aoc.Solve(solver, part, bytes.NewReader(input))
+					}
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package days
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"aoc24/aoc"
+)
+
+// the answer files and inputs are relative to the repository root
+const root = ".."
+
+func TestGolden(t *testing.T) {
+	expectations, err := aoc.ReadExpectations(filepath.Join(root, "answers.txt"))
+	if err != nil {
+		t.Fatalf("unable to read answers: %v", err)
+	}
+
+	covered := map[int]bool{}
+	for _, e := range expectations {
+		covered[e.Day] = true
+	}
+
+	for _, day := range aoc.Days() {
+		if !covered[day] {
+			t.Errorf("day %d has no expected answers", day)
+		}
+	}
+
+	runGolden(t, expectations, false)
+}
+
+func TestGoldenPrivate(t *testing.T) {
+	expectations, err := aoc.ReadExpectations(filepath.Join(root, "answers.private.txt"))
+	if errors.Is(err, os.ErrNotExist) {
+		t.Skip("no private answers present")
+	}
+
+	if err != nil {
+		t.Fatalf("unable to read private answers: %v", err)
+	}
+
+	// the private inputs are personal, so they may not be present at all
+	runGolden(t, expectations, true)
+}
+
+// runGolden solves the inputs of the expectations and checks the answers.
+// Missing inputs are skipped when optional and fail the test otherwise.
+func runGolden(t *testing.T, expectations []aoc.Expectation, optional bool) {
+	for _, e := range expectations {
+		t.Run(fmt.Sprintf("day %d/%s", e.Day, e.Input), func(t *testing.T) {
+			solver, ok := aoc.Lookup(e.Day)
+			if !ok {
+				t.Fatalf("day %d is not registered", e.Day)
+			}
+
+			input, err := os.ReadFile(filepath.Join(root, e.Input))
+			if optional && errors.Is(err, os.ErrNotExist) {
+				t.Skipf("input %s not present", e.Input)
+			}
+
+			if err != nil {
+				t.Fatalf("unable to read input: %v", err)
+			}
+
+			for _, part := range aoc.Parts {
+				expected, known := e.Answers[part]
+				if !known {
+					continue
+				}
+
+				t.Run(part.String(), func(t *testing.T) {
+					answer, err := aoc.Solve(solver, part, bytes.NewReader(input))
+					if err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+
+					if answer != expected {
+						t.Fatalf("wrong answer. Expected %d, got %d", expected, answer)
+					}
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Package difftest compares two implementations of the same function, such
+// as a naive and an optimized solver, on generated inputs. When they disagree
+// the input is shrunk to a minimal counterexample before it's reported.
+package difftest
+
+import (
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"testing"
+)
+
+// Case describes two implementations to compare and how to come up with
+// inputs for them.
+type Case[I any, O comparable] struct {
+	// Generate produces a random input.
+	Generate func(rng *rand.Rand) I
+
+	// Shrink yields smaller variations of an input, simplest first. It's
+	// optional, without it counterexamples are reported as generated.
+	Shrink func(input I) iter.Seq[I]
+
+	// Feasible reports whether an input lies within the range both
+	// implementations are meant to handle, such as the range where a brute
+	// force approach still finishes. Both generated and shrunk inputs outside
+	// of it are skipped. It's optional, by default every input is feasible.
+	Feasible func(input I) bool
+
+	// Naive is the reference implementation.
+	Naive func(input I) O
+
+	// Optimized is the implementation checked against the reference.
+	Optimized func(input I) O
+}
+
+// Counterexample is an input on which the implementations disagree, either
+// by returning different results or by panicking.
+type Counterexample[I any, O comparable] struct {
+	Input     I
+	Naive     O
+	Optimized O
+
+	// NaivePanic and OptimizedPanic hold the values the implementations
+	// panicked with, if they did.
+	NaivePanic     any
+	OptimizedPanic any
+}
+
+func (c Counterexample[I, O]) String() string {
+	describe := func(result O, panicked any) string {
+		if panicked != nil {
+			return fmt.Sprintf("panicked with %v", panicked)
+		}
+
+		return fmt.Sprintf("returned %+v", result)
+	}
+
+	return fmt.Sprintf("input %+v: naive %s, optimized %s",
+		c.Input,
+		describe(c.Naive, c.NaivePanic),
+		describe(c.Optimized, c.OptimizedPanic),
+	)
+}
+
+// Check compares the implementations on n inputs generated from seed. It
+// returns the minimized counterexample of the first input they disagree on.
+func (c Case[I, O]) Check(seed uint64, n int) (Counterexample[I, O], bool) {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	for range n {
+		input := c.Generate(rng)
+		if !c.feasible(input) {
+			continue
+		}
+
+		if ce, diverged := c.compare(input); diverged {
+			return c.minimize(ce), true
+		}
+	}
+
+	return Counterexample[I, O]{}, false
+}
+
+// Run checks the implementations on n generated inputs, failing t with the
+// minimized counterexample when they disagree.
+func (c Case[I, O]) Run(t testing.TB, n int) {
+	t.Helper()
+	if ce, diverged := c.Check(1, n); diverged {
+		t.Fatalf("implementations diverge on %s", ce)
+	}
+}
+
+func (c Case[I, O]) feasible(input I) bool {
+	return c.Feasible == nil || c.Feasible(input)
+}
+
+func (c Case[I, O]) compare(input I) (Counterexample[I, O], bool) {
+	ce := Counterexample[I, O]{Input: input}
+	ce.Naive, ce.NaivePanic = call(c.Naive, input)
+	ce.Optimized, ce.OptimizedPanic = call(c.Optimized, input)
+
+	diverged := ce.Naive != ce.Optimized || ce.NaivePanic != nil || ce.OptimizedPanic != nil
+	return ce, diverged
+}
+
+// call calls fn, recovering from any panic so it can be reported along with
+// the input causing it.
+func call[I any, O comparable](fn func(I) O, input I) (result O, panicked any) {
+	defer func() {
+		panicked = recover()
+	}()
+
+	return fn(input), nil
+}
+
+// minimize greedily replaces the counterexample by the first smaller input
+// that still makes the implementations disagree, until none of the smaller
+// inputs does.
+func (c Case[I, O]) minimize(ce Counterexample[I, O]) Counterexample[I, O] {
+	if c.Shrink == nil {
+		return ce
+	}
+
+	for shrunk := true; shrunk; {
+		shrunk = false
+		for candidate := range c.Shrink(ce.Input) {
+			if !c.feasible(candidate) {
+				continue
+			}
+
+			if smaller, diverged := c.compare(candidate); diverged {
+				ce, shrunk = smaller, true
+				break
+			}
+		}
+	}
+
+	return ce
+}
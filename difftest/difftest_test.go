@@ -0,0 +1,104 @@
+package difftest
+
+import (
+	"iter"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func assert(t *testing.T, a, b any, msg string) {
+	t.Helper()
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("assertion failed: %s. Expected %+v, got %+v", msg, b, a)
+	}
+}
+
+func sum(nums []int) int {
+	var total int
+	for _, n := range nums {
+		total += n
+	}
+
+	return total
+}
+
+// sumCapped gets the sum wrong as soon as a number exceeds 40
+func sumCapped(nums []int) int {
+	var total int
+	for _, n := range nums {
+		total += min(n, 40)
+	}
+
+	return total
+}
+
+var sumCase = Case[[]int, int]{
+	Generate: func(rng *rand.Rand) []int {
+		nums := make([]int, 1+rng.IntN(20))
+		for idx := range nums {
+			nums[idx] = rng.IntN(100)
+		}
+
+		return nums
+	},
+	Shrink: func(nums []int) iter.Seq[[]int] {
+		return Concat(ShrinkSlice(nums), ShrinkElements(nums, ShrinkInt))
+	},
+	Naive: sum,
+}
+
+func TestCheck(t *testing.T) {
+	c := sumCase
+	c.Optimized = sum
+
+	_, diverged := c.Check(1, 100)
+	assert(t, diverged, false, "equal implementations shouldn't diverge")
+
+	c.Optimized = sumCapped
+	ce, diverged := c.Check(1, 100)
+	assert(t, diverged, true, "expected implementations to diverge")
+	assert(t, ce.Input, []int{41}, "counterexample isn't minimal")
+	assert(t, ce.Naive, 41, "incorrect naive result")
+	assert(t, ce.Optimized, 40, "incorrect optimized result")
+
+	// the smallest counterexample is out of reach
+	c.Feasible = func(nums []int) bool {
+		return !slices.Contains(nums, 41)
+	}
+
+	ce, diverged = c.Check(1, 100)
+	assert(t, diverged, true, "expected implementations to diverge")
+	assert(t, ce.Input, []int{42}, "counterexample isn't minimal")
+}
+
+func TestShrink(t *testing.T) {
+	assert(t, slices.Collect(ShrinkInt(10)), []int{0, 5, 9}, "incorrect shrunk ints")
+	assert(t, slices.Collect(ShrinkInt(-3)), []int{0, -1, -2}, "incorrect shrunk ints")
+	assert(t, slices.Collect(ShrinkInt(1)), []int{0}, "incorrect shrunk ints")
+	assert(t, len(slices.Collect(ShrinkInt(0))), 0, "zero can't shrink")
+
+	assert(t, slices.Collect(ShrinkSlice([]int{1, 2, 3, 4})), [][]int{
+		{3, 4}, {1, 2},
+		{2, 3, 4}, {1, 3, 4}, {1, 2, 4}, {1, 2, 3},
+	}, "incorrect shrunk slices")
+	assert(t, slices.Collect(ShrinkSlice([]int{1})), [][]int{{}}, "incorrect shrunk slices")
+}
+
+func TestCheckPanic(t *testing.T) {
+	c := sumCase
+	c.Optimized = func(nums []int) int {
+		if slices.Contains(nums, 7) {
+			panic("unlucky number")
+		}
+
+		return sum(nums)
+	}
+
+	ce, diverged := c.Check(1, 100)
+	assert(t, diverged, true, "expected panic to count as divergence")
+	assert(t, ce.Input, []int{7}, "counterexample isn't minimal")
+	assert(t, ce.OptimizedPanic, "unlucky number", "expected optimized to panic")
+	assert(t, ce.NaivePanic, nil, "naive shouldn't panic")
+}
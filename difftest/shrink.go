@@ -0,0 +1,81 @@
+package difftest
+
+import (
+	"iter"
+	"slices"
+)
+
+// ShrinkSlice yields copies of s with parts removed, starting with the first
+// and second half and working down to single elements.
+func ShrinkSlice[T any](s []T) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		for size := len(s) / 2; size > 0; size /= 2 {
+			for start := 0; start+size <= len(s); start += size {
+				if !yield(slices.Delete(slices.Clone(s), start, start+size)) {
+					return
+				}
+			}
+		}
+
+		if len(s) == 1 && !yield([]T{}) {
+			return
+		}
+	}
+}
+
+// ShrinkElements yields copies of s where a single element is replaced by
+// one of its shrunk values.
+func ShrinkElements[T any](s []T, shrink func(T) iter.Seq[T]) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		for idx, elem := range s {
+			for shrunk := range shrink(elem) {
+				c := slices.Clone(s)
+				c[idx] = shrunk
+				if !yield(c) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ShrinkInt yields values closer to zero than n, starting with zero itself.
+func ShrinkInt(n int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		if n == 0 {
+			return
+		}
+
+		candidates := []int{0, n / 2, n - sign(n)}
+		for idx, v := range candidates {
+			if slices.Contains(candidates[:idx], v) {
+				continue
+			}
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Concat yields the values of every sequence in order.
+func Concat[T any](seqs ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, seq := range seqs {
+			for v := range seq {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func sign(n int) int {
+	if n < 0 {
+		return -1
+	}
+
+	return 1
+}
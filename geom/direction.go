@@ -0,0 +1,33 @@
+package geom
+
+var (
+	DirectionNorth     = Vector{X: 0, Y: -1}
+	DirectionNorthEast = Vector{X: 1, Y: -1}
+	DirectionEast      = Vector{X: 1, Y: 0}
+	DirectionSouthEast = Vector{X: 1, Y: 1}
+	DirectionSouth     = Vector{X: 0, Y: 1}
+	DirectionSouthWest = Vector{X: -1, Y: 1}
+	DirectionWest      = Vector{X: -1, Y: 0}
+	DirectionNorthWest = Vector{X: -1, Y: -1}
+
+	// OrthogonalDirections lists the four directions that don't move
+	// diagonally, clockwise starting north.
+	OrthogonalDirections = []Vector{
+		DirectionNorth,
+		DirectionEast,
+		DirectionSouth,
+		DirectionWest,
+	}
+
+	// AllDirections lists all eight directions, clockwise starting north.
+	AllDirections = []Vector{
+		DirectionNorth,
+		DirectionNorthEast,
+		DirectionEast,
+		DirectionSouthEast,
+		DirectionSouth,
+		DirectionSouthWest,
+		DirectionWest,
+		DirectionNorthWest,
+	}
+)
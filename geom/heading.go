@@ -0,0 +1,90 @@
+package geom
+
+import (
+	"fmt"
+)
+
+// Heading is one of the four orthogonal directions something can face.
+type Heading int
+
+const (
+	HeadingNorth Heading = iota
+	HeadingEast
+	HeadingSouth
+	HeadingWest
+)
+
+var Headings = []Heading{
+	HeadingNorth,
+	HeadingEast,
+	HeadingSouth,
+	HeadingWest,
+}
+
+func (h Heading) Vector() Vector {
+	switch h {
+	case HeadingNorth:
+		return DirectionNorth
+
+	case HeadingEast:
+		return DirectionEast
+
+	case HeadingSouth:
+		return DirectionSouth
+
+	case HeadingWest:
+		return DirectionWest
+	}
+
+	panic(fmt.Errorf("invalid heading: %d", h))
+}
+
+// HeadingFromVector returns the heading v points in. It panics when v
+// doesn't point in one of the orthogonal directions.
+func HeadingFromVector(v Vector) Heading {
+	switch {
+	case v.X == 0 && v.Y < 0:
+		return HeadingNorth
+
+	case v.X > 0 && v.Y == 0:
+		return HeadingEast
+
+	case v.X == 0 && v.Y > 0:
+		return HeadingSouth
+
+	case v.X < 0 && v.Y == 0:
+		return HeadingWest
+	}
+
+	panic(fmt.Errorf("unable to determine heading from vector: %+v", v))
+}
+
+func (h Heading) RotateClockwise() Heading {
+	return (h + 1) % 4
+}
+
+func (h Heading) RotateCounterClockwise() Heading {
+	return (h + 3) % 4
+}
+
+func (h Heading) Reverse() Heading {
+	return (h + 2) % 4
+}
+
+func (h Heading) String() string {
+	switch h {
+	case HeadingNorth:
+		return "north"
+
+	case HeadingEast:
+		return "east"
+
+	case HeadingSouth:
+		return "south"
+
+	case HeadingWest:
+		return "west"
+	}
+
+	return fmt.Sprintf("Heading(%d)", int(h))
+}
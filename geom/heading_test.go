@@ -0,0 +1,27 @@
+package geom
+
+import (
+	"testing"
+)
+
+func TestHeadingRotation(t *testing.T) {
+	assert(t, HeadingNorth.RotateClockwise(), HeadingEast, "incorrect clockwise rotation")
+	assert(t, HeadingWest.RotateClockwise(), HeadingNorth, "clockwise rotation not wrapping")
+	assert(t, HeadingNorth.RotateCounterClockwise(), HeadingWest, "counter-clockwise rotation not wrapping")
+	assert(t, HeadingSouth.RotateCounterClockwise(), HeadingEast, "incorrect counter-clockwise rotation")
+	assert(t, HeadingEast.Reverse(), HeadingWest, "incorrect reversal")
+	assert(t, HeadingWest.Reverse(), HeadingEast, "incorrect reversal")
+
+	for _, h := range Headings {
+		assert(t, h.RotateClockwise().RotateCounterClockwise(), h, "rotations don't cancel out")
+		assert(t, h.RotateClockwise().RotateClockwise(), h.Reverse(), "two rotations not a reversal")
+	}
+}
+
+func TestHeadingVector(t *testing.T) {
+	for _, h := range Headings {
+		assert(t, HeadingFromVector(h.Vector()), h, "heading doesn't round trip")
+		assert(t, HeadingFromVector(h.Vector().Scale(5)), h, "heading of scaled vector incorrect")
+		assert(t, h.Reverse().Vector(), h.Vector().Scale(-1), "reversed vector incorrect")
+	}
+}
@@ -0,0 +1,71 @@
+package geom
+
+type Vector struct {
+	X, Y int
+}
+
+func (v Vector) Add(v2 Vector) Vector {
+	return Vector{
+		X: v.X + v2.X,
+		Y: v.Y + v2.Y,
+	}
+}
+
+func (v Vector) Sub(v2 Vector) Vector {
+	return Vector{
+		X: v.X - v2.X,
+		Y: v.Y - v2.Y,
+	}
+}
+
+func (v Vector) Scale(n int) Vector {
+	return Vector{
+		X: v.X * n,
+		Y: v.Y * n,
+	}
+}
+
+// Manhattan returns the distance between v and v2 when only moving
+// horizontally and vertically.
+func (v Vector) Manhattan(v2 Vector) int {
+	d := v.Sub(v2)
+	return abs(d.X) + abs(d.Y)
+}
+
+// Chebyshev returns the distance between v and v2 when diagonal moves are
+// allowed too, which is the number of king moves on a chess board.
+func (v Vector) Chebyshev(v2 Vector) int {
+	d := v.Sub(v2)
+	return max(abs(d.X), abs(d.Y))
+}
+
+// Reduce divides both components by their greatest common divisor, resulting
+// in the smallest vector pointing in the same direction. Eg; (4, -6) reduces
+// to (2, -3). The zero vector reduces to itself.
+func (v Vector) Reduce() Vector {
+	d := gcd(abs(v.X), abs(v.Y))
+	if d == 0 {
+		return v
+	}
+
+	return Vector{
+		X: v.X / d,
+		Y: v.Y / d,
+	}
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+
+	return a
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return a * -1
+	}
+
+	return a
+}
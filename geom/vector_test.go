@@ -0,0 +1,40 @@
+package geom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func assert(t *testing.T, a, b any, msg string) {
+	t.Helper()
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("assertion failed: %s. Expected %+v, got %+v", msg, b, a)
+	}
+}
+
+func TestVectorArithmetic(t *testing.T) {
+	a := Vector{X: 3, Y: -2}
+	b := Vector{X: -1, Y: 4}
+
+	assert(t, a.Add(b), Vector{X: 2, Y: 2}, "incorrect sum")
+	assert(t, a.Sub(b), Vector{X: 4, Y: -6}, "incorrect difference")
+	assert(t, a.Scale(3), Vector{X: 9, Y: -6}, "incorrect scale")
+	assert(t, a.Scale(-1), Vector{X: -3, Y: 2}, "incorrect negative scale")
+}
+
+func TestVectorDistances(t *testing.T) {
+	a := Vector{X: 3, Y: -2}
+	b := Vector{X: -1, Y: 4}
+
+	assert(t, a.Manhattan(b), 10, "incorrect manhattan distance")
+	assert(t, b.Manhattan(a), 10, "manhattan distance not symmetric")
+	assert(t, a.Chebyshev(b), 6, "incorrect chebyshev distance")
+	assert(t, a.Manhattan(a), 0, "distance to self not zero")
+}
+
+func TestVectorReduce(t *testing.T) {
+	assert(t, Vector{X: 4, Y: -6}.Reduce(), Vector{X: 2, Y: -3}, "incorrect reduction")
+	assert(t, Vector{X: -5, Y: 0}.Reduce(), Vector{X: -1, Y: 0}, "incorrect reduction")
+	assert(t, Vector{X: 3, Y: 7}.Reduce(), Vector{X: 3, Y: 7}, "coprime vector reduced")
+	assert(t, Vector{}.Reduce(), Vector{}, "zero vector reduced")
+}
@@ -1,5 +1,5 @@
-module aoc24/day16
+module aoc24
 
 go 1.23.6
 
-require github.com/tmw/go-prioqueue v0.0.0-20250212193650-2cf2cf8a5a78 // indirect
+require github.com/tmw/go-prioqueue v0.0.0-20250212193650-2cf2cf8a5a78
@@ -0,0 +1,283 @@
+package grid
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+
+	"aoc24/geom"
+)
+
+// Grid is a rectangular grid of cells, stored row by row in a single slice.
+type Grid[T any] struct {
+	cells  []T
+	width  int
+	height int
+}
+
+// New returns a grid of the given size where every cell holds the zero value.
+func New[T any](width, height int) Grid[T] {
+	return Grid[T]{
+		cells:  make([]T, width*height),
+		width:  width,
+		height: height,
+	}
+}
+
+// FromCells returns a grid backed by cells, where every width cells form a row.
+func FromCells[T any](cells []T, width int) Grid[T] {
+	if width <= 0 || len(cells)%width != 0 {
+		panic(fmt.Errorf("%d cells don't fit in rows of width %d", len(cells), width))
+	}
+
+	return Grid[T]{
+		cells:  cells,
+		width:  width,
+		height: len(cells) / width,
+	}
+}
+
+func (g *Grid[T]) Width() int {
+	return g.width
+}
+
+func (g *Grid[T]) Height() int {
+	return g.height
+}
+
+func (g *Grid[T]) InBounds(pos geom.Vector) bool {
+	return pos.X >= 0 && pos.Y >= 0 &&
+		pos.X < g.width && pos.Y < g.height
+}
+
+// At returns the cell at pos and panics when pos is out of bounds.
+func (g *Grid[T]) At(pos geom.Vector) T {
+	if !g.InBounds(pos) {
+		panic(fmt.Errorf("position %+v out of bounds", pos))
+	}
+
+	return g.cells[pos.Y*g.width+pos.X]
+}
+
+// Get returns the cell at pos, or false when pos is out of bounds.
+func (g *Grid[T]) Get(pos geom.Vector) (T, bool) {
+	if !g.InBounds(pos) {
+		var zero T
+		return zero, false
+	}
+
+	return g.cells[pos.Y*g.width+pos.X], true
+}
+
+// Set updates the cell at pos and panics when pos is out of bounds.
+func (g *Grid[T]) Set(pos geom.Vector, val T) {
+	if !g.InBounds(pos) {
+		panic(fmt.Errorf("position %+v out of bounds", pos))
+	}
+
+	g.cells[pos.Y*g.width+pos.X] = val
+}
+
+func (g *Grid[T]) Clone() Grid[T] {
+	return Grid[T]{
+		cells:  slices.Clone(g.cells),
+		width:  g.width,
+		height: g.height,
+	}
+}
+
+// All iterates over every cell and its position, row by row.
+func (g *Grid[T]) All() iter.Seq2[geom.Vector, T] {
+	return func(yield func(geom.Vector, T) bool) {
+		for idx, cell := range g.cells {
+			pos := geom.Vector{X: idx % g.width, Y: idx / g.width}
+			if !yield(pos, cell) {
+				return
+			}
+		}
+	}
+}
+
+// Rows iterates over the rows from top to bottom. The rows share their
+// memory with the grid, so changing them changes the grid.
+func (g *Grid[T]) Rows() iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		for y := 0; y < g.height; y++ {
+			start := y * g.width
+			end := start + g.width
+			if !yield(g.cells[start:end:end]) {
+				return
+			}
+		}
+	}
+}
+
+// Row returns a view of the row at y. The row is empty when y is out of
+// bounds.
+func (g *Grid[T]) Row(y int) Line[T] {
+	if y < 0 || y >= g.height {
+		return Line[T]{}
+	}
+
+	return g.line(geom.Vector{X: 0, Y: y}, geom.Vector{X: 1, Y: 0}, g.width)
+}
+
+// Column returns a view of the column at x. The column is empty when x is
+// out of bounds.
+func (g *Grid[T]) Column(x int) Line[T] {
+	if x < 0 || x >= g.width {
+		return Line[T]{}
+	}
+
+	return g.line(geom.Vector{X: x, Y: 0}, geom.Vector{X: 0, Y: 1}, g.height)
+}
+
+// Columns iterates over views of the columns from left to right.
+func (g *Grid[T]) Columns() iter.Seq[Line[T]] {
+	return func(yield func(Line[T]) bool) {
+		for x := 0; x < g.width; x++ {
+			if !yield(g.Column(x)) {
+				return
+			}
+		}
+	}
+}
+
+// Diagonal returns a view of a single diagonal that starts at the given
+// position and runs downwards until it leaves the grid. The direction
+// determines which way the diagonal runs,
+//
+// passing +1 iterates through left-to-right
+// passing -1 iterates through right-to-left
+//
+// The diagonal is empty when pos is out of bounds.
+func (g *Grid[T]) Diagonal(pos geom.Vector, direction int) Line[T] {
+	if !g.InBounds(pos) {
+		return Line[T]{}
+	}
+
+	length := g.height - pos.Y
+	if direction > 0 {
+		length = min(length, g.width-pos.X)
+	} else {
+		length = min(length, pos.X+1)
+	}
+
+	return g.line(pos, geom.Vector{X: direction, Y: 1}, length)
+}
+
+// Diagonals iterates over views of all left-to-right diagonals, starting in
+// the bottom left corner, followed by all right-to-left diagonals, starting
+// in the bottom right corner.
+func (g *Grid[T]) Diagonals() iter.Seq[Line[T]] {
+	return func(yield func(Line[T]) bool) {
+		// iterate left-to-right
+		x, y := 0, g.height-1
+		for y >= 0 && x < g.width {
+			if !yield(g.Diagonal(geom.Vector{X: x, Y: y}, +1)) {
+				return
+			}
+
+			if y > 0 {
+				y--
+			} else {
+				x++
+			}
+		}
+
+		// iterate right-to-left
+		x, y = g.width-1, g.height-1
+		for y >= 0 && x >= 0 {
+			if !yield(g.Diagonal(geom.Vector{X: x, Y: y}, -1)) {
+				return
+			}
+
+			if y > 0 {
+				y--
+			} else {
+				x--
+			}
+		}
+	}
+}
+
+// Lines iterates over views of every row, column and diagonal, in that order.
+func (g *Grid[T]) Lines() iter.Seq[Line[T]] {
+	return func(yield func(Line[T]) bool) {
+		for y := 0; y < g.height; y++ {
+			if !yield(g.Row(y)) {
+				return
+			}
+		}
+
+		for l := range g.Columns() {
+			if !yield(l) {
+				return
+			}
+		}
+
+		for l := range g.Diagonals() {
+			if !yield(l) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbours4 iterates over the orthogonally adjacent positions of pos that
+// lie within the grid, clockwise starting north.
+func (g *Grid[T]) Neighbours4(pos geom.Vector) iter.Seq[geom.Vector] {
+	return g.neighbours(pos, geom.OrthogonalDirections)
+}
+
+// Neighbours8 iterates over the orthogonally and diagonally adjacent positions
+// of pos that lie within the grid, clockwise starting north.
+func (g *Grid[T]) Neighbours8(pos geom.Vector) iter.Seq[geom.Vector] {
+	return g.neighbours(pos, geom.AllDirections)
+}
+
+func (g *Grid[T]) neighbours(pos geom.Vector, directions []geom.Vector) iter.Seq[geom.Vector] {
+	return func(yield func(geom.Vector) bool) {
+		for _, dir := range directions {
+			neighbour := pos.Add(dir)
+			if !g.InBounds(neighbour) {
+				continue
+			}
+
+			if !yield(neighbour) {
+				return
+			}
+		}
+	}
+}
+
+// Render draws the grid row by row using renderFn to draw each cell.
+func (g *Grid[T]) Render(renderFn func(pos geom.Vector, cell T) string) string {
+	var b strings.Builder
+	for pos, cell := range g.All() {
+		if pos.X == 0 && pos.Y > 0 {
+			b.WriteByte('\n')
+		}
+
+		b.WriteString(renderFn(pos, cell))
+	}
+
+	return b.String()
+}
+
+// String renders bytes and runes as characters and any other cell using its
+// default format.
+func (g Grid[T]) String() string {
+	return g.Render(func(_ geom.Vector, cell T) string {
+		switch c := any(cell).(type) {
+		case byte:
+			return string(rune(c))
+
+		case rune:
+			return string(c)
+		}
+
+		return fmt.Sprint(cell)
+	})
+}
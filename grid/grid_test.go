@@ -0,0 +1,339 @@
+package grid
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"aoc24/geom"
+	"aoc24/parse"
+)
+
+func assert(t *testing.T, a, b any, msg string) {
+	t.Helper()
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("assertion failed: %s. Expected %+v, got %+v", msg, b, a)
+	}
+}
+
+func mustParse(t *testing.T, input string) Grid[byte] {
+	t.Helper()
+	g, err := ParseBytes(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error parsing grid: %v", err)
+	}
+
+	return g
+}
+
+func TestParse(t *testing.T) {
+	g := mustParse(t, "abc\ndef\nghi\n")
+	assert(t, g.Width(), 3, "grid width incorrect")
+	assert(t, g.Height(), 3, "grid height incorrect")
+	assert(t, g.At(geom.Vector{X: 0, Y: 0}), byte('a'), "incorrect char")
+	assert(t, g.At(geom.Vector{X: 1, Y: 0}), byte('b'), "incorrect char")
+	assert(t, g.At(geom.Vector{X: 0, Y: 1}), byte('d'), "incorrect char")
+	assert(t, g.At(geom.Vector{X: 2, Y: 1}), byte('f'), "incorrect char")
+	assert(t, g.At(geom.Vector{X: 2, Y: 2}), byte('i'), "incorrect char")
+
+	t.Run("without trailing newline", func(t *testing.T) {
+		g := mustParse(t, "abc\ndef\nghi")
+		assert(t, g.Height(), 3, "grid height incorrect")
+		assert(t, g.At(geom.Vector{X: 2, Y: 2}), byte('i'), "incorrect char")
+	})
+
+	t.Run("stops at empty line", func(t *testing.T) {
+		g := mustParse(t, "ab\r\ncd\r\n\r\nrest")
+		assert(t, g.Width(), 2, "grid width incorrect")
+		assert(t, g.Height(), 2, "grid height incorrect")
+	})
+
+	t.Run("ragged rows", func(t *testing.T) {
+		_, err := ParseBytes(strings.NewReader("abc\nde\n"))
+		var perr *parse.Error
+		assert(t, errors.As(err, &perr), true, "expected parse error for ragged rows")
+		assert(t, perr.Line, 2, "incorrect line")
+		assert(t, perr.Column, 3, "incorrect column")
+		assert(t, perr.Text, "de", "incorrect text")
+	})
+
+	t.Run("invalid cell", func(t *testing.T) {
+		_, err := Parse(strings.NewReader("..\n.x\n"), func(_ geom.Vector, c byte) (bool, error) {
+			if c != '.' {
+				return false, fmt.Errorf("invalid cell")
+			}
+
+			return true, nil
+		})
+		var perr *parse.Error
+		assert(t, errors.As(err, &perr), true, "expected parse error for invalid cell")
+		assert(t, perr.Line, 2, "incorrect line")
+		assert(t, perr.Column, 2, "incorrect column")
+		assert(t, perr.Text, "x", "incorrect text")
+	})
+}
+
+func TestGetSet(t *testing.T) {
+	g := mustParse(t, "ab\ncd\n")
+
+	_, ok := g.Get(geom.Vector{X: 2, Y: 0})
+	assert(t, ok, false, "expected out of bounds")
+
+	_, ok = g.Get(geom.Vector{X: 0, Y: -1})
+	assert(t, ok, false, "expected out of bounds")
+
+	c, ok := g.Get(geom.Vector{X: 1, Y: 1})
+	assert(t, ok, true, "expected within bounds")
+	assert(t, c, byte('d'), "incorrect char")
+
+	clone := g.Clone()
+	g.Set(geom.Vector{X: 1, Y: 1}, 'x')
+	assert(t, g.At(geom.Vector{X: 1, Y: 1}), byte('x'), "set not applied")
+	assert(t, clone.At(geom.Vector{X: 1, Y: 1}), byte('d'), "clone shares cells")
+}
+
+func TestRows(t *testing.T) {
+	g := mustParse(t, "abc\ndef\nghi\n")
+	h := slices.Collect(g.Rows())
+	assert(t, len(h), 3, "incorrect length")
+	assert(t, h[0], []byte("abc"), "incorrect first line")
+	assert(t, h[1], []byte("def"), "incorrect second line")
+	assert(t, h[2], []byte("ghi"), "incorrect third line")
+}
+
+func TestColumns(t *testing.T) {
+	g := mustParse(t, "abc\ndef\nghi\n")
+	h := slices.Collect(g.Columns())
+	assert(t, len(h), 3, "incorrect length")
+	assert(t, h[0].Collect(), []byte("adg"), "incorrect first line")
+	assert(t, h[1].Collect(), []byte("beh"), "incorrect second line")
+	assert(t, h[2].Collect(), []byte("cfi"), "incorrect third line")
+}
+
+func TestDiagonal(t *testing.T) {
+	g := mustParse(t, "abc\ndef\nghi\n")
+
+	t.Run("left-to-right", func(t *testing.T) {
+		assert(t, g.Diagonal(geom.Vector{X: 0, Y: 0}, +1).Collect(), []byte("aei"), "wrong diagonal")
+		assert(t, g.Diagonal(geom.Vector{X: 1, Y: 0}, +1).Collect(), []byte("bf"), "wrong diagonal")
+		assert(t, g.Diagonal(geom.Vector{X: 2, Y: 0}, +1).Collect(), []byte("c"), "wrong diagonal")
+		assert(t, g.Diagonal(geom.Vector{X: 0, Y: 1}, +1).Collect(), []byte("dh"), "wrong diagonal")
+		assert(t, g.Diagonal(geom.Vector{X: 0, Y: 2}, +1).Collect(), []byte("g"), "wrong diagonal")
+	})
+
+	t.Run("right-to-left", func(t *testing.T) {
+		assert(t, g.Diagonal(geom.Vector{X: 2, Y: 0}, -1).Collect(), []byte("ceg"), "wrong diagonal")
+		assert(t, g.Diagonal(geom.Vector{X: 1, Y: 0}, -1).Collect(), []byte("bd"), "wrong diagonal")
+		assert(t, g.Diagonal(geom.Vector{X: 0, Y: 0}, -1).Collect(), []byte("a"), "wrong diagonal")
+		assert(t, g.Diagonal(geom.Vector{X: 2, Y: 1}, -1).Collect(), []byte("fh"), "wrong diagonal")
+		assert(t, g.Diagonal(geom.Vector{X: 2, Y: 2}, -1).Collect(), []byte("i"), "wrong diagonal")
+	})
+}
+
+func TestDiagonals(t *testing.T) {
+	g := mustParse(t, "abc\ndef\nghi\n")
+	h := slices.Collect(g.Diagonals())
+
+	// a b c
+	// d e f
+	// g h i
+	//
+	// bcomes =
+	//  left to right       right to left
+	// - g                   i
+	// - d h                 f h
+	// - a e i               c e g
+	// - b f                 b d
+	// - c                   a
+	assert(t, len(h), 10, "incorrect length")
+	assert(t, h[0].Collect(), []byte("g"), "incorrect first line")
+	assert(t, h[1].Collect(), []byte("dh"), "incorrect second line")
+	assert(t, h[2].Collect(), []byte("aei"), "incorrect third line")
+	assert(t, h[3].Collect(), []byte("bf"), "incorrect third line")
+	assert(t, h[4].Collect(), []byte("c"), "incorrect third line")
+	assert(t, h[5].Collect(), []byte("i"), "incorrect first line")
+	assert(t, h[6].Collect(), []byte("fh"), "incorrect second line")
+	assert(t, h[7].Collect(), []byte("ceg"), "incorrect third line")
+	assert(t, h[8].Collect(), []byte("bd"), "incorrect third line")
+	assert(t, h[9].Collect(), []byte("a"), "incorrect third line")
+}
+
+func TestLine(t *testing.T) {
+	g := mustParse(t, "abcd\nefgh\nijkl\n")
+
+	row := g.Row(1)
+	assert(t, row.Len(), 4, "incorrect row length")
+	assert(t, row.Collect(), []byte("efgh"), "incorrect row")
+
+	column := g.Column(3)
+	assert(t, column.Len(), 3, "incorrect column length")
+	assert(t, column.At(2), byte('l'), "incorrect cell in column")
+
+	diagonal := g.Diagonal(geom.Vector{X: 1, Y: 0}, +1)
+	assert(t, diagonal.Collect(), []byte("bgl"), "incorrect diagonal")
+	assert(t, g.Diagonal(geom.Vector{X: 3, Y: 0}, -1).Collect(), []byte("dgj"), "incorrect anti-diagonal")
+	assert(t, g.Diagonal(geom.Vector{X: 4, Y: 0}, +1).Len(), 0, "diagonal out of bounds should be empty")
+
+	for _, l := range []Line[byte]{g.Row(-1), g.Row(3), g.Column(-1), g.Column(4)} {
+		assert(t, l.Len(), 0, "line out of bounds should be empty")
+		assert(t, Count(l, []byte("e")), 0, "line out of bounds shouldn't match")
+	}
+
+	g.Set(geom.Vector{X: 2, Y: 1}, 'x')
+	assert(t, diagonal.At(1), byte('x'), "view should share cells with the grid")
+
+	var cells []byte
+	for idx, cell := range row.All() {
+		assert(t, idx, len(cells), "incorrect index")
+		cells = append(cells, cell)
+	}
+	assert(t, cells, []byte("efxh"), "incorrect cells")
+
+	assert(t, len(slices.Collect(g.Lines())), 3+4+6+6, "incorrect number of lines")
+}
+
+func TestLine_Count(t *testing.T) {
+	g := mustParse(t, "xmasamx\nmmmmmmm\n")
+
+	tcs := []struct {
+		sub   string
+		count int
+	}{
+		{"xmas", 1},
+		{"amx", 1},
+		{"a", 2},
+		{"mxx", 0},
+		{"xmasamxx", 0},
+		{"", 0},
+	}
+
+	for _, tc := range tcs {
+		assert(t, Count(g.Row(0), []byte(tc.sub)), tc.count, fmt.Sprintf("incorrect count of %q", tc.sub))
+	}
+
+	assert(t, Count(g.Row(1), []byte("mm")), 6, "overlapping occurrences should be counted")
+	assert(t, Count(g.Column(1), []byte("mm")), 1, "incorrect count in column")
+}
+
+func TestNeighbours(t *testing.T) {
+	g := New[int](3, 3)
+
+	corner := slices.Collect(g.Neighbours4(geom.Vector{X: 0, Y: 0}))
+	assert(t, corner, []geom.Vector{{X: 1, Y: 0}, {X: 0, Y: 1}}, "incorrect corner neighbours")
+
+	center := slices.Collect(g.Neighbours4(geom.Vector{X: 1, Y: 1}))
+	assert(t, center, []geom.Vector{{X: 1, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 2}, {X: 0, Y: 1}}, "incorrect center neighbours")
+
+	corner = slices.Collect(g.Neighbours8(geom.Vector{X: 2, Y: 2}))
+	assert(t, corner, []geom.Vector{{X: 2, Y: 1}, {X: 1, Y: 2}, {X: 1, Y: 1}}, "incorrect corner neighbours")
+
+	center = slices.Collect(g.Neighbours8(geom.Vector{X: 1, Y: 1}))
+	assert(t, len(center), 8, "incorrect number of center neighbours")
+}
+
+func TestRender(t *testing.T) {
+	g := mustParse(t, "ab\ncd\n")
+	assert(t, g.String(), "ab\ncd", "incorrect string")
+
+	numbers := FromCells([]int{1, 2, 3, 4, 5, 6}, 3)
+	assert(t, numbers.String(), "123\n456", "incorrect string")
+
+	rendered := numbers.Render(func(_ geom.Vector, cell int) string {
+		if cell%2 == 0 {
+			return "#"
+		}
+
+		return "."
+	})
+	assert(t, rendered, ".#.\n#.#", "incorrect render")
+}
+
+func TestWordSearch(t *testing.T) {
+	// a b c
+	// d e f
+	// g h i
+	g := mustParse(t, "abc\ndef\nghi\n")
+	ws := NewWordSearch([]byte("aei"), []byte("fed"), []byte("ea"), []byte("heb"), []byte("xyz"), []byte{})
+
+	assert(t, ws.Find(&g), []Match{
+		{Word: 0, Pos: geom.Vector{X: 0, Y: 0}, Direction: geom.DirectionSouthEast},
+		{Word: 2, Pos: geom.Vector{X: 1, Y: 1}, Direction: geom.DirectionNorthWest},
+		{Word: 1, Pos: geom.Vector{X: 2, Y: 1}, Direction: geom.DirectionWest},
+		{Word: 3, Pos: geom.Vector{X: 1, Y: 2}, Direction: geom.DirectionNorth},
+	}, "incorrect matches")
+
+	m := Match{Pos: geom.Vector{X: 1, Y: 2}, Direction: geom.DirectionNorthEast}
+	assert(t, m.Cells(2), []geom.Vector{{X: 1, Y: 2}, {X: 2, Y: 1}}, "incorrect cells")
+}
+
+func TestWordSearch_SharedPrefixes(t *testing.T) {
+	g := mustParse(t, "XMASAMX\n")
+	ws := NewWordSearch([]byte("XM"), []byte("XMAS"), []byte("XMASAMX"), []byte("SAM"))
+
+	var found []string
+	for _, m := range ws.Find(&g) {
+		found = append(found, fmt.Sprintf("%s at %d heading %+v", ws.Words()[m.Word], m.Pos.X, m.Direction))
+	}
+
+	assert(t, found, []string{
+		"XM at 0 heading {X:1 Y:0}",
+		"XMAS at 0 heading {X:1 Y:0}",
+		"XMASAMX at 0 heading {X:1 Y:0}",
+		"SAM at 3 heading {X:1 Y:0}",
+		"SAM at 3 heading {X:-1 Y:0}",
+		"XM at 6 heading {X:-1 Y:0}",
+		"XMAS at 6 heading {X:-1 Y:0}",
+		"XMASAMX at 6 heading {X:-1 Y:0}",
+	}, "every word sharing a prefix should be found in the same walk")
+}
+
+func mustTemplate(t *testing.T, s string) Template[byte] {
+	t.Helper()
+	tmpl, err := ParseTemplate(s, '.')
+	if err != nil {
+		t.Fatalf("unexpected error parsing template: %v", err)
+	}
+
+	return tmpl
+}
+
+func TestTemplate_Transform(t *testing.T) {
+	tmpl := mustTemplate(t, "ab.\nd.f\n")
+
+	rotated := tmpl.Rotate()
+	assert(t, rotated.cells.String(), "da\n.b\nf.", "incorrect rotation")
+	assert(t, rotated.wild.cells, []bool{false, false, true, false, false, true}, "wildcards should rotate along")
+
+	reflected := tmpl.Reflect()
+	assert(t, reflected.cells.String(), ".ba\nf.d", "incorrect reflection")
+
+	assert(t, tmpl.Rotate().Rotate().Rotate().Rotate().equal(tmpl), true, "four rotations should be a full turn")
+}
+
+func TestPattern_Find(t *testing.T) {
+	g := mustParse(t, "M.S.M\n.A.A.\nM.S.M\n")
+
+	p := NewPattern(mustTemplate(t, "M.S\n.A.\nM.S\n"), false, false)
+	assert(t, len(p.Variants), 1, "a pattern without rotations has a single variant")
+	assert(t, p.Find(&g), []PatternMatch{{Pos: geom.Vector{X: 0, Y: 0}}}, "incorrect matches")
+
+	// the X shaped pattern is symmetric, reflecting it adds nothing beyond
+	// its four rotations
+	p = NewPattern(mustTemplate(t, "M.S\n.A.\nM.S\n"), true, true)
+	assert(t, len(p.Variants), 4, "incorrect number of variants")
+	assert(t, p.Find(&g), []PatternMatch{
+		{Pos: geom.Vector{X: 0, Y: 0}, Variant: 0},
+		{Pos: geom.Vector{X: 2, Y: 0}, Variant: 1},
+	}, "incorrect matches")
+
+	// an L shape has eight variants
+	p = NewPattern(mustTemplate(t, "a.\na.\naa\n"), true, true)
+	assert(t, len(p.Variants), 8, "incorrect number of variants")
+
+	// templates larger than the grid never match
+	p = NewPattern(mustTemplate(t, "....\n....\n....\n....\n....\n"), false, false)
+	assert(t, len(p.Find(&g)), 0, "oversized template shouldn't match")
+}
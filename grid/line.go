@@ -0,0 +1,118 @@
+package grid
+
+import (
+	"fmt"
+	"iter"
+
+	"aoc24/geom"
+)
+
+// Line is a view of a straight line of cells in a grid, such as a row, a
+// column or a diagonal. It shares its memory with the grid, so it's cheap to
+// create and changes to the grid show through it.
+type Line[T any] struct {
+	cells  []T
+	start  int
+	stride int
+	length int
+}
+
+// line returns the view of length cells starting at pos and running in dir.
+// The caller makes sure every cell lies within the grid.
+func (g *Grid[T]) line(pos, dir geom.Vector, length int) Line[T] {
+	if length <= 0 {
+		return Line[T]{}
+	}
+
+	return Line[T]{
+		cells:  g.cells,
+		start:  pos.Y*g.width + pos.X,
+		stride: dir.Y*g.width + dir.X,
+		length: length,
+	}
+}
+
+// Len returns the number of cells in the line.
+func (l Line[T]) Len() int {
+	return l.length
+}
+
+// At returns the i-th cell of the line and panics when i is out of range.
+func (l Line[T]) At(i int) T {
+	if i < 0 || i >= l.length {
+		panic(fmt.Errorf("index %d out of range for line of length %d", i, l.length))
+	}
+
+	return l.cells[l.start+i*l.stride]
+}
+
+// All iterates over every cell and its index, from the start of the line.
+func (l Line[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range l.length {
+			if !yield(i, l.cells[l.start+i*l.stride]) {
+				return
+			}
+		}
+	}
+}
+
+// Collect copies the cells of the line into a new slice.
+func (l Line[T]) Collect() []T {
+	res := make([]T, l.length)
+	for i := range res {
+		res[i] = l.cells[l.start+i*l.stride]
+	}
+
+	return res
+}
+
+// Count returns the number of times sub occurs in the line, including
+// occurrences that overlap. An empty sub is never counted.
+func Count[T comparable](l Line[T], sub []T) int {
+	if len(sub) == 0 {
+		return 0
+	}
+
+	n := 0
+	for range matches(l, sub) {
+		n++
+	}
+
+	return n
+}
+
+// matches iterates over the index of every occurrence of sub, which must not
+// be empty, in the line.
+func matches[T comparable](l Line[T], sub []T) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		// compare against the first cell of sub before walking the rest of
+		// it, most candidates fail right there
+		first, rest := sub[0], sub[1:]
+
+		idx := l.start
+		for i := range l.length - len(rest) {
+			if l.cells[idx] == first && hasPrefix(l.cells, idx+l.stride, l.stride, rest) {
+				if !yield(i) {
+					return
+				}
+			}
+
+			idx += l.stride
+		}
+	}
+}
+
+// hasPrefix reports whether the cells starting at idx and taking strides
+// begin with sub.
+func hasPrefix[T comparable](cells []T, idx, stride int, sub []T) bool {
+	for _, c := range sub {
+		if cells[idx] != c {
+			return false
+		}
+
+		idx += stride
+	}
+
+	return true
+}
@@ -0,0 +1,76 @@
+package grid
+
+import (
+	"errors"
+	"io"
+
+	"aoc24/geom"
+	"aoc24/parse"
+)
+
+// Parse reads a grid from input where each line is a row and each byte is a
+// cell, converted into a cell by parseFn. Parsing stops at the end of the
+// input or at the first empty line, whichever comes first.
+//
+// Errors returned by parseFn are reported as a *parse.Error pointing at the
+// offending cell.
+func Parse[T any](input io.Reader, parseFn func(pos geom.Vector, c byte) (T, error)) (Grid[T], error) {
+	var (
+		r = parse.NewReader(input)
+		g Grid[T]
+	)
+
+	for line := range r.Lines() {
+		if line.Text == "" {
+			break
+		}
+
+		if g.height == 0 {
+			g.width = len(line.Text)
+		}
+
+		if len(line.Text) != g.width {
+			return Grid[T]{}, parse.Errorf(line.Number, min(len(line.Text), g.width)+1, line.Text,
+				"row has width %d, expected %d", len(line.Text), g.width)
+		}
+
+		for x := range len(line.Text) {
+			c := line.Text[x]
+			cell, err := parseFn(geom.Vector{X: x, Y: g.height}, c)
+			if err != nil {
+				return Grid[T]{}, cellError(line.Number, x+1, c, err)
+			}
+
+			g.cells = append(g.cells, cell)
+		}
+
+		g.height++
+	}
+
+	if err := r.Err(); err != nil {
+		return Grid[T]{}, err
+	}
+
+	return g, nil
+}
+
+func cellError(line, column int, c byte, err error) error {
+	var perr *parse.Error
+	if errors.As(err, &perr) {
+		return err
+	}
+
+	return &parse.Error{
+		Line:   line,
+		Column: column,
+		Text:   string(c),
+		Err:    err,
+	}
+}
+
+// ParseBytes reads a grid from input where every byte is a cell.
+func ParseBytes(input io.Reader) (Grid[byte], error) {
+	return Parse(input, func(_ geom.Vector, c byte) (byte, error) {
+		return c, nil
+	})
+}
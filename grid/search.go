@@ -0,0 +1,109 @@
+package grid
+
+import (
+	"aoc24/geom"
+)
+
+// Match is a word found in a grid.
+type Match struct {
+	// Word is the index of the word in the list of words searched for.
+	Word int
+
+	// Pos is the position of the first cell of the word, from which it runs
+	// in Direction.
+	Pos       geom.Vector
+	Direction geom.Vector
+}
+
+// Cells returns the positions of the cells making up the match, given the
+// length of the word.
+func (m Match) Cells(length int) []geom.Vector {
+	cells := make([]geom.Vector, length)
+	for idx := range cells {
+		cells[idx] = m.Pos.Add(m.Direction.Scale(idx))
+	}
+
+	return cells
+}
+
+type trie[T comparable] struct {
+	children map[T]*trie[T]
+
+	// words holds the indices of the words ending at this node
+	words []int
+}
+
+func (t *trie[T]) insert(word []T, idx int) {
+	node := t
+	for _, c := range word {
+		child, found := node.children[c]
+		if !found {
+			child = &trie[T]{children: map[T]*trie[T]{}}
+			node.children[c] = child
+		}
+
+		node = child
+	}
+
+	node.words = append(node.words, idx)
+}
+
+// WordSearch finds words in a grid running in any of the eight directions.
+// The words are kept in a trie, so every word starting at a cell in a given
+// direction is matched in a single walk.
+type WordSearch[T comparable] struct {
+	root  *trie[T]
+	words [][]T
+}
+
+// NewWordSearch returns a search for the given words. Empty words are never
+// found.
+func NewWordSearch[T comparable](words ...[]T) *WordSearch[T] {
+	ws := &WordSearch[T]{
+		root:  &trie[T]{children: map[T]*trie[T]{}},
+		words: words,
+	}
+
+	for idx, word := range words {
+		if len(word) > 0 {
+			ws.root.insert(word, idx)
+		}
+	}
+
+	return ws
+}
+
+// Words returns the words searched for.
+func (ws *WordSearch[T]) Words() [][]T {
+	return ws.words
+}
+
+// Find returns every occurrence of the words in g, ordered by position and
+// then by direction, clockwise starting north. A word of a single cell is
+// found once for every direction.
+func (ws *WordSearch[T]) Find(g *Grid[T]) []Match {
+	var matches []Match
+	for pos := range g.All() {
+		for _, dir := range geom.AllDirections {
+			var (
+				node = ws.root
+				cur  = pos
+			)
+
+			for g.InBounds(cur) {
+				node = node.children[g.cells[cur.Y*g.width+cur.X]]
+				if node == nil {
+					break
+				}
+
+				for _, word := range node.words {
+					matches = append(matches, Match{Word: word, Pos: pos, Direction: dir})
+				}
+
+				cur = cur.Add(dir)
+			}
+		}
+	}
+
+	return matches
+}
@@ -0,0 +1,169 @@
+package grid
+
+import (
+	"slices"
+	"strings"
+
+	"aoc24/geom"
+)
+
+// Template is a small grid of cells matched against areas of a larger grid
+// of the same size. Wildcard cells match any cell.
+type Template[T comparable] struct {
+	cells Grid[T]
+	wild  Grid[bool]
+}
+
+// NewTemplate returns a template of the given cells, where every cell holding
+// wildcard matches any cell.
+func NewTemplate[T comparable](cells Grid[T], wildcard T) Template[T] {
+	t := Template[T]{
+		cells: cells.Clone(),
+		wild:  New[bool](cells.width, cells.height),
+	}
+
+	for idx, cell := range t.cells.cells {
+		t.wild.cells[idx] = cell == wildcard
+	}
+
+	return t
+}
+
+// ParseTemplate parses a template of bytes, one row per line, where every
+// wildcard byte matches any cell.
+func ParseTemplate(s string, wildcard byte) (Template[byte], error) {
+	g, err := ParseBytes(strings.NewReader(s))
+	if err != nil {
+		return Template[byte]{}, err
+	}
+
+	return NewTemplate(g, wildcard), nil
+}
+
+func (t Template[T]) Width() int {
+	return t.cells.width
+}
+
+func (t Template[T]) Height() int {
+	return t.cells.height
+}
+
+// transform returns a template of the given size where every cell is taken
+// from the position of this template that src maps it to.
+func (t Template[T]) transform(width, height int, src func(x, y int) geom.Vector) Template[T] {
+	res := Template[T]{
+		cells: New[T](width, height),
+		wild:  New[bool](width, height),
+	}
+
+	for y := range height {
+		for x := range width {
+			from := src(x, y)
+			res.cells.cells[y*width+x] = t.cells.At(from)
+			res.wild.cells[y*width+x] = t.wild.At(from)
+		}
+	}
+
+	return res
+}
+
+// Rotate returns the template rotated a quarter turn clockwise.
+func (t Template[T]) Rotate() Template[T] {
+	return t.transform(t.Height(), t.Width(), func(x, y int) geom.Vector {
+		return geom.Vector{X: y, Y: t.Height() - 1 - x}
+	})
+}
+
+// Reflect returns the template mirrored left to right.
+func (t Template[T]) Reflect() Template[T] {
+	return t.transform(t.Width(), t.Height(), func(x, y int) geom.Vector {
+		return geom.Vector{X: t.Width() - 1 - x, Y: y}
+	})
+}
+
+func (t Template[T]) equal(o Template[T]) bool {
+	return t.cells.width == o.cells.width &&
+		slices.Equal(t.cells.cells, o.cells.cells) &&
+		slices.Equal(t.wild.cells, o.wild.cells)
+}
+
+// MatchAt reports whether the template matches the area of g with its top
+// left corner at pos.
+func (t Template[T]) MatchAt(g *Grid[T], pos geom.Vector) bool {
+	if pos.X < 0 || pos.Y < 0 || pos.X+t.Width() > g.width || pos.Y+t.Height() > g.height {
+		return false
+	}
+
+	for y := range t.Height() {
+		var (
+			row  = g.cells[(pos.Y+y)*g.width+pos.X:]
+			tidx = y * t.Width()
+		)
+
+		for x := range t.Width() {
+			if !t.wild.cells[tidx+x] && t.cells.cells[tidx+x] != row[x] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Pattern is a template along with the variations of it that match as well.
+type Pattern[T comparable] struct {
+	// Variants holds the distinct variations of the template, starting with
+	// the template itself.
+	Variants []Template[T]
+}
+
+// NewPattern returns a pattern matching the template, optionally rotated by
+// any number of quarter turns and mirrored.
+func NewPattern[T comparable](t Template[T], rotations, reflections bool) Pattern[T] {
+	candidates := []Template[T]{t}
+	if reflections {
+		candidates = append(candidates, t.Reflect())
+	}
+
+	if rotations {
+		for _, c := range slices.Clone(candidates) {
+			for range 3 {
+				c = c.Rotate()
+				candidates = append(candidates, c)
+			}
+		}
+	}
+
+	var p Pattern[T]
+	for _, c := range candidates {
+		if !slices.ContainsFunc(p.Variants, c.equal) {
+			p.Variants = append(p.Variants, c)
+		}
+	}
+
+	return p
+}
+
+// PatternMatch is an area of a grid matched by a pattern.
+type PatternMatch struct {
+	// Pos is the top left corner of the area.
+	Pos geom.Vector
+
+	// Variant is the index of the variant that matched.
+	Variant int
+}
+
+// Find returns every area of g matched by any of the variants of the
+// pattern, ordered by position and then by variant.
+func (p Pattern[T]) Find(g *Grid[T]) []PatternMatch {
+	var matches []PatternMatch
+	for pos := range g.All() {
+		for idx, v := range p.Variants {
+			if v.MatchAt(g, pos) {
+				matches = append(matches, PatternMatch{Pos: pos, Variant: idx})
+			}
+		}
+	}
+
+	return matches
+}
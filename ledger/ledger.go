@@ -0,0 +1,195 @@
+// Package ledger keeps track of the answers submitted for a day and what the
+// website made of them, so a wrong answer isn't submitted twice.
+package ledger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"aoc24/aoc"
+)
+
+// Verdict is what became of a submitted answer.
+type Verdict string
+
+const (
+	VerdictCorrect Verdict = "correct"
+	VerdictTooHigh Verdict = "too-high"
+	VerdictTooLow  Verdict = "too-low"
+
+	// VerdictWrong is a wrong answer without a hint whether it's too high
+	// or too low.
+	VerdictWrong Verdict = "wrong"
+)
+
+// ParseVerdict parses a verdict as it's written in the ledger.
+func ParseVerdict(s string) (Verdict, error) {
+	switch v := Verdict(s); v {
+	case VerdictCorrect, VerdictTooHigh, VerdictTooLow, VerdictWrong:
+		return v, nil
+	}
+
+	return "", fmt.Errorf("invalid verdict %q, expected correct, too-high, too-low or wrong", s)
+}
+
+// Entry records a single submitted answer.
+type Entry struct {
+	Part    aoc.Part  `json:"part"`
+	Answer  int       `json:"answer"`
+	Verdict Verdict   `json:"verdict"`
+	Time    time.Time `json:"time"`
+}
+
+// Ledger holds the answers submitted for a single day, oldest first.
+type Ledger struct {
+	path    string
+	Entries []Entry
+}
+
+// Open reads the ledger stored at path. A missing ledger is empty and is
+// created once the first entry is recorded.
+func Open(path string) (*Ledger, error) {
+	l := &Ledger{path: path}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	dec := json.NewDecoder(f)
+	for {
+		var e Entry
+		err := dec.Decode(&e)
+		if errors.Is(err, io.EOF) {
+			return l, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to read ledger %s: %w", path, err)
+		}
+
+		l.Entries = append(l.Entries, e)
+	}
+}
+
+// Record appends e to the ledger, writing it to disk right away.
+func (l *Ledger) Record(e Entry) error {
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if err := json.NewEncoder(f).Encode(e); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	l.Entries = append(l.Entries, e)
+	return nil
+}
+
+// Bounds returns the range the answer to part must lie within, exclusive on
+// both ends: above the highest answer that was too low and below the lowest
+// answer that was too high. A side without such an answer is unbounded.
+func (l *Ledger) Bounds(part aoc.Part) (low, high *int) {
+	for _, e := range l.Entries {
+		if e.Part != part {
+			continue
+		}
+
+		switch e.Verdict {
+		case VerdictTooLow:
+			if low == nil || e.Answer > *low {
+				low = &e.Answer
+			}
+
+		case VerdictTooHigh:
+			if high == nil || e.Answer < *high {
+				high = &e.Answer
+			}
+		}
+	}
+
+	return low, high
+}
+
+// Correct returns the correct answer to part, if it's known.
+func (l *Ledger) Correct(part aoc.Part) (int, bool) {
+	for _, e := range l.Entries {
+		if e.Part == part && e.Verdict == VerdictCorrect {
+			return e.Answer, true
+		}
+	}
+
+	return 0, false
+}
+
+// Check reports whether answer can still be the answer to part, returning a
+// *ConflictError when it's known to be wrong.
+func (l *Ledger) Check(part aoc.Part, answer int) error {
+	if correct, found := l.Correct(part); found {
+		if answer == correct {
+			return nil
+		}
+
+		return &ConflictError{Part: part, Answer: answer, Reason: fmt.Sprintf("the correct answer is %d", correct)}
+	}
+
+	for _, e := range l.Entries {
+		if e.Part == part && e.Answer == answer {
+			return &ConflictError{Part: part, Answer: answer, Reason: fmt.Sprintf("it was submitted on %s and is %s", e.Time.Format(time.DateTime), e.Verdict.describe())}
+		}
+	}
+
+	low, high := l.Bounds(part)
+	if low != nil && answer <= *low {
+		return &ConflictError{Part: part, Answer: answer, Reason: fmt.Sprintf("%d is too low", *low)}
+	}
+
+	if high != nil && answer >= *high {
+		return &ConflictError{Part: part, Answer: answer, Reason: fmt.Sprintf("%d is too high", *high)}
+	}
+
+	return nil
+}
+
+func (v Verdict) describe() string {
+	switch v {
+	case VerdictTooHigh:
+		return "too high"
+
+	case VerdictTooLow:
+		return "too low"
+	}
+
+	return string(v)
+}
+
+// ConflictError is returned by Check for an answer that's known to be wrong.
+type ConflictError struct {
+	Part   aoc.Part
+	Answer int
+	Reason string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s: %d is wrong, %s", e.Part, e.Answer, e.Reason)
+}
@@ -0,0 +1,88 @@
+package ledger
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"aoc24/aoc"
+)
+
+func assert(t *testing.T, a, b any, msg string) {
+	t.Helper()
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("assertion failed: %s. Expected %+v, got %+v", msg, b, a)
+	}
+}
+
+func TestLedger_Record(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "2024", "day_15.answers.ndjson")
+
+	l, err := Open(path)
+	assert(t, err, nil, "missing ledger should open")
+	assert(t, len(l.Entries), 0, "missing ledger should be empty")
+
+	entries := []Entry{
+		{Part: aoc.PartOne, Answer: 1527563, Verdict: VerdictCorrect, Time: time.Date(2024, 12, 15, 7, 0, 0, 0, time.UTC)},
+		{Part: aoc.PartTwo, Answer: 1488820, Verdict: VerdictTooHigh, Time: time.Date(2024, 12, 15, 9, 0, 0, 0, time.UTC)},
+	}
+
+	for _, e := range entries {
+		assert(t, l.Record(e), nil, "unexpected error recording entry")
+	}
+
+	l, err = Open(path)
+	assert(t, err, nil, "unexpected error reopening ledger")
+	assert(t, l.Entries, entries, "entries should survive a reopen")
+}
+
+func TestLedger_Check(t *testing.T) {
+	l := &Ledger{Entries: []Entry{
+		{Part: aoc.PartOne, Answer: 100, Verdict: VerdictTooLow},
+		{Part: aoc.PartOne, Answer: 500, Verdict: VerdictTooHigh},
+		{Part: aoc.PartOne, Answer: 300, Verdict: VerdictTooHigh},
+		{Part: aoc.PartOne, Answer: 200, Verdict: VerdictWrong},
+		{Part: aoc.PartTwo, Answer: 42, Verdict: VerdictCorrect},
+	}}
+
+	low, high := l.Bounds(aoc.PartOne)
+	assert(t, *low, 100, "lower bound")
+	assert(t, *high, 300, "upper bound")
+
+	tests := []struct {
+		part   aoc.Part
+		answer int
+		ok     bool
+	}{
+		{aoc.PartOne, 150, true},
+		{aoc.PartOne, 299, true},
+		{aoc.PartOne, 200, false},
+		{aoc.PartOne, 100, false},
+		{aoc.PartOne, 50, false},
+		{aoc.PartOne, 300, false},
+		{aoc.PartOne, 400, false},
+		{aoc.PartTwo, 42, true},
+		{aoc.PartTwo, 43, false},
+	}
+
+	for _, test := range tests {
+		err := l.Check(test.part, test.answer)
+
+		var conflict *ConflictError
+		assert(t, !errors.As(err, &conflict), test.ok, "check of "+test.part.String()+" answer")
+	}
+
+	low, high = (&Ledger{}).Bounds(aoc.PartOne)
+	assert(t, low == nil && high == nil, true, "empty ledger should be unbounded")
+}
+
+func TestParseVerdict(t *testing.T) {
+	v, err := ParseVerdict("too-high")
+	assert(t, err, nil, "unexpected error")
+	assert(t, v, VerdictTooHigh, "incorrect verdict")
+
+	_, err = ParseVerdict("too high")
+	assert(t, err != nil, true, "invalid verdict should be rejected")
+}
@@ -0,0 +1,32 @@
+package parse
+
+import (
+	"fmt"
+)
+
+// Error describes malformed input, pointing at the offending text. Lines and
+// columns start counting at 1, columns are counted in bytes.
+type Error struct {
+	Line   int
+	Column int
+	Text   string
+	Err    error
+}
+
+// Errorf returns an *Error for text found at the given line and column.
+func Errorf(line, column int, text string, format string, args ...any) *Error {
+	return &Error{
+		Line:   line,
+		Column: column,
+		Text:   text,
+		Err:    fmt.Errorf(format, args...),
+	}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d, column %d: %v: %q", e.Line, e.Column, e.Err, e.Text)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,52 @@
+package parse
+
+import (
+	"strconv"
+	"unicode"
+)
+
+// Field is a piece of text along with the column it starts at.
+type Field struct {
+	Text   string
+	Column int
+}
+
+// Fields splits s around runs of whitespace, like strings.Fields, but keeps
+// track of the column each field starts at.
+func Fields(s string) []Field {
+	var (
+		fields []Field
+		start  = -1
+	)
+
+	for idx, r := range s {
+		if unicode.IsSpace(r) {
+			if start >= 0 {
+				fields = append(fields, Field{Text: s[start:idx], Column: start + 1})
+				start = -1
+			}
+			continue
+		}
+
+		if start < 0 {
+			start = idx
+		}
+	}
+
+	if start >= 0 {
+		fields = append(fields, Field{Text: s[start:], Column: start + 1})
+	}
+
+	return fields
+}
+
+// Int converts the field into an integer, returning an *Error pointing at the
+// field when it isn't one.
+func (f Field) Int(line int) (int, error) {
+	num, err := strconv.Atoi(f.Text)
+	if err != nil {
+		return 0, Errorf(line, f.Column, f.Text, "not an integer")
+	}
+
+	return num, nil
+}
@@ -0,0 +1,32 @@
+package parse
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func assert(t *testing.T, a, b any, msg string) {
+	t.Helper()
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("assertion failed: %s. Expected %+v, got %+v", msg, b, a)
+	}
+}
+
+func TestFields(t *testing.T) {
+	assert(t, Fields("3   4"), []Field{{Text: "3", Column: 1}, {Text: "4", Column: 5}}, "incorrect fields")
+	assert(t, Fields("  12\tab  "), []Field{{Text: "12", Column: 3}, {Text: "ab", Column: 6}}, "incorrect fields")
+	assert(t, len(Fields(" \t ")), 0, "expected no fields")
+}
+
+func TestFieldInt(t *testing.T) {
+	num, err := Field{Text: "-42", Column: 3}.Int(7)
+	assert(t, err, nil, "unexpected error")
+	assert(t, num, -42, "incorrect number")
+
+	_, err = Field{Text: "4x", Column: 3}.Int(7)
+	var perr *Error
+	assert(t, errors.As(err, &perr), true, "expected a parse error")
+	assert(t, *perr, Error{Line: 7, Column: 3, Text: "4x", Err: perr.Err}, "incorrect parse error")
+	assert(t, err.Error(), `line 7, column 3: not an integer: "4x"`, "incorrect message")
+}
@@ -0,0 +1,149 @@
+package parse
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"iter"
+	"strings"
+)
+
+// Reader streams an input line by line, block by block or rune by rune,
+// keeping track of the position within the input. Unlike a bufio.Scanner
+// there's no limit to the length of a line, while only the line at hand is
+// held in memory.
+//
+// Iteration stops at the end of the input or at the first read error, which
+// is returned by Err afterwards. The iterators all consume the same input, so
+// a parser can, for example, read a block of lines before reading the rest
+// rune by rune.
+type Reader struct {
+	r    *bufio.Reader
+	line int
+	err  error
+}
+
+// Line is a single line of input without its line ending, either "\n" or
+// "\r\n". Number is 1-based.
+type Line struct {
+	Number int
+	Text   string
+}
+
+// Block is a group of consecutive lines that aren't empty.
+type Block struct {
+	Lines []Line
+}
+
+// Rune is a single rune of input. Line and Column are 1-based, where the
+// column is a byte offset within the line like it is for Error. Every line,
+// including the last one, ends with a '\n' rune, whatever its line ending.
+type Rune struct {
+	Value  rune
+	Line   int
+	Column int
+}
+
+func NewReader(input io.Reader) *Reader {
+	return &Reader{r: bufio.NewReader(input)}
+}
+
+// Err returns the first error, other than io.EOF, encountered while reading.
+func (r *Reader) Err() error {
+	return r.err
+}
+
+// Lines iterates over the remaining lines of the input.
+func (r *Reader) Lines() iter.Seq[Line] {
+	return func(yield func(Line) bool) {
+		for {
+			line, ok := r.readLine()
+			if !ok || !yield(line) {
+				return
+			}
+		}
+	}
+}
+
+// Blocks iterates over the remaining blocks of the input, skipping the empty
+// lines separating them.
+func (r *Reader) Blocks() iter.Seq[Block] {
+	return func(yield func(Block) bool) {
+		var block Block
+		for line := range r.Lines() {
+			if line.Text != "" {
+				block.Lines = append(block.Lines, line)
+				continue
+			}
+
+			if len(block.Lines) == 0 {
+				continue
+			}
+
+			if !yield(block) {
+				return
+			}
+
+			block = Block{}
+		}
+
+		if len(block.Lines) > 0 {
+			yield(block)
+		}
+	}
+}
+
+// Runes iterates over the remaining runes of the input. Invalid UTF-8 is
+// yielded as utf8.RuneError, one byte at a time.
+func (r *Reader) Runes() iter.Seq[Rune] {
+	return func(yield func(Rune) bool) {
+		for {
+			line, ok := r.readLine()
+			if !ok {
+				return
+			}
+
+			for idx, c := range line.Text {
+				if !yield(Rune{Value: c, Line: line.Number, Column: idx + 1}) {
+					return
+				}
+			}
+
+			if !yield(Rune{Value: '\n', Line: line.Number, Column: len(line.Text) + 1}) {
+				return
+			}
+		}
+	}
+}
+
+func (r *Reader) readLine() (Line, bool) {
+	if r.err != nil {
+		return Line{}, false
+	}
+
+	text, err := r.r.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		r.err = err
+		return Line{}, false
+	}
+
+	if text == "" {
+		return Line{}, false
+	}
+
+	text = strings.TrimSuffix(text, "\n")
+	text = strings.TrimSuffix(text, "\r")
+
+	r.line++
+	return Line{Number: r.line, Text: text}, true
+}
+
+// Text returns the lines of the block joined by newlines.
+func (b Block) Text() string {
+	texts := make([]string, len(b.Lines))
+	for idx, line := range b.Lines {
+		texts[idx] = line.Text
+	}
+
+	return strings.Join(texts, "\n")
+}
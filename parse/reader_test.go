@@ -0,0 +1,64 @@
+package parse
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestReaderLines(t *testing.T) {
+	r := NewReader(strings.NewReader("ab\r\n\ncd"))
+	assert(t, slices.Collect(r.Lines()), []Line{{1, "ab"}, {2, ""}, {3, "cd"}}, "incorrect lines")
+	assert(t, r.Err(), nil, "unexpected error")
+
+	t.Run("long lines", func(t *testing.T) {
+		long := strings.Repeat("x", 1<<20)
+		r := NewReader(strings.NewReader(long + "\n" + long))
+		lines := slices.Collect(r.Lines())
+		assert(t, len(lines), 2, "incorrect number of lines")
+		assert(t, len(lines[1].Text), 1<<20, "line got truncated")
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		failure := errors.New("disk on fire")
+		r := NewReader(io.MultiReader(strings.NewReader("ab\ncd\n"), iotest.ErrReader(failure)))
+		assert(t, slices.Collect(r.Lines()), []Line{{1, "ab"}, {2, "cd"}}, "incorrect lines")
+		assert(t, r.Err(), failure, "expected read error")
+	})
+}
+
+func TestReaderBlocks(t *testing.T) {
+	r := NewReader(strings.NewReader("\na\nb\n\n\nc\n"))
+	blocks := slices.Collect(r.Blocks())
+	assert(t, blocks, []Block{
+		{Lines: []Line{{2, "a"}, {3, "b"}}},
+		{Lines: []Line{{6, "c"}}},
+	}, "incorrect blocks")
+	assert(t, blocks[0].Text(), "a\nb", "incorrect block text")
+}
+
+func TestReaderRunes(t *testing.T) {
+	r := NewReader(strings.NewReader("aé\nb"))
+	assert(t, slices.Collect(r.Runes()), []Rune{
+		{'a', 1, 1}, {'é', 1, 2}, {'\n', 1, 4},
+		{'b', 2, 1}, {'\n', 2, 2},
+	}, "incorrect runes")
+}
+
+func TestReaderMixed(t *testing.T) {
+	r := NewReader(strings.NewReader("a\nb\n\nxy\n"))
+	for block := range r.Blocks() {
+		assert(t, block.Text(), "a\nb", "incorrect block")
+		break
+	}
+
+	var runes []rune
+	for c := range r.Runes() {
+		runes = append(runes, c.Value)
+	}
+
+	assert(t, string(runes), "xy\n", "incorrect remaining runes")
+}
@@ -0,0 +1,105 @@
+// Package prof writes CPU and memory profiles and execution traces of a
+// solver, and summarizes the profiles without needing the pprof tool.
+package prof
+
+import (
+	"errors"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+)
+
+// Options holds the paths to write the profiles and trace to. Empty paths
+// are skipped.
+type Options struct {
+	CPUProfile string
+	MemProfile string
+	Trace      string
+}
+
+// Start starts the CPU profile and execution trace. The returned stop
+// function stops them again and writes the memory profile, so everything
+// that happened in between ends up in the profiles.
+func Start(opts Options) (stop func() error, err error) {
+	var stops []func() error
+	stopAll := func() error {
+		var errs []error
+		for _, stop := range stops {
+			errs = append(errs, stop())
+		}
+
+		return errors.Join(errs...)
+	}
+
+	if opts.CPUProfile != "" {
+		f, err := os.Create(opts.CPUProfile)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := pprof.StartCPUProfile(f); err != nil {
+			f.Close()
+			return nil, err
+		}
+
+		stops = append(stops, func() error {
+			pprof.StopCPUProfile()
+			return f.Close()
+		})
+	}
+
+	if opts.Trace != "" {
+		f, err := os.Create(opts.Trace)
+		if err != nil {
+			stopAll()
+			return nil, err
+		}
+
+		if err := trace.Start(f); err != nil {
+			f.Close()
+			stopAll()
+			return nil, err
+		}
+
+		stops = append(stops, func() error {
+			trace.Stop()
+			return f.Close()
+		})
+	}
+
+	if opts.MemProfile != "" {
+		stops = append(stops, func() error {
+			return writeMemProfile(opts.MemProfile)
+		})
+	}
+
+	return stopAll, nil
+}
+
+func writeMemProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	// get up-to-date statistics
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// Summarize reads the profile at path and summarizes it, see Top.
+func Summarize(path, sampleType string, n int) (Summary, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Summary{}, err
+	}
+	defer f.Close()
+
+	return Top(f, sampleType, n)
+}
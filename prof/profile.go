@@ -0,0 +1,186 @@
+package prof
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+)
+
+// profile holds the parts of a pprof profile needed to summarize it, see
+// https://github.com/google/pprof/blob/main/proto/profile.proto for the
+// complete format.
+type profile struct {
+	sampleTypes []valueType
+	samples     []sample
+
+	// locations maps the id of a location to the ids of the functions it
+	// covers, innermost first when functions got inlined.
+	locations map[uint64][]uint64
+
+	// functions maps the id of a function to its name in the string table.
+	functions map[uint64]int64
+
+	strings []string
+}
+
+type valueType struct {
+	typ, unit int64
+}
+
+type sample struct {
+	locations []uint64
+	values    []int64
+}
+
+// parseProfile decodes a profile as written by runtime/pprof, which may or
+// may not be gzip compressed.
+func parseProfile(r io.Reader) (*profile, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > 2 && data[0] == 0x1f && data[1] == 0x8b {
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+
+		if data, err = io.ReadAll(gz); err != nil {
+			return nil, err
+		}
+	}
+
+	p := &profile{
+		locations: map[uint64][]uint64{},
+		functions: map[uint64]int64{},
+	}
+
+	err = fields(data, func(f field) error {
+		switch f.number {
+		case 1: // sample_type
+			vt, err := parseValueType(f.data)
+			p.sampleTypes = append(p.sampleTypes, vt)
+			return err
+
+		case 2: // sample
+			s, err := parseSample(f.data)
+			p.samples = append(p.samples, s)
+			return err
+
+		case 4: // location
+			return p.parseLocation(f.data)
+
+		case 5: // function
+			return p.parseFunction(f.data)
+
+		case 6: // string_table
+			p.strings = append(p.strings, string(f.data))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid profile: %w", err)
+	}
+
+	return p, nil
+}
+
+func parseValueType(msg []byte) (valueType, error) {
+	var vt valueType
+	err := fields(msg, func(f field) error {
+		switch f.number {
+		case 1:
+			vt.typ = int64(f.num)
+
+		case 2:
+			vt.unit = int64(f.num)
+		}
+
+		return nil
+	})
+
+	return vt, err
+}
+
+func parseSample(msg []byte) (sample, error) {
+	var s sample
+	err := fields(msg, func(f field) error {
+		switch f.number {
+		case 1: // location_id
+			ids, err := f.uints()
+			s.locations = append(s.locations, ids...)
+			return err
+
+		case 2: // value
+			values, err := f.uints()
+			for _, v := range values {
+				s.values = append(s.values, int64(v))
+			}
+			return err
+		}
+
+		return nil
+	})
+
+	return s, err
+}
+
+func (p *profile) parseLocation(msg []byte) error {
+	var (
+		id        uint64
+		functions []uint64
+	)
+
+	err := fields(msg, func(f field) error {
+		switch f.number {
+		case 1: // id
+			id = f.num
+
+		case 4: // line
+			return fields(f.data, func(f field) error {
+				if f.number == 1 { // function_id
+					functions = append(functions, f.num)
+				}
+
+				return nil
+			})
+		}
+
+		return nil
+	})
+
+	p.locations[id] = functions
+	return err
+}
+
+func (p *profile) parseFunction(msg []byte) error {
+	var id uint64
+	var name int64
+
+	err := fields(msg, func(f field) error {
+		switch f.number {
+		case 1: // id
+			id = f.num
+
+		case 2: // name
+			name = int64(f.num)
+		}
+
+		return nil
+	})
+
+	p.functions[id] = name
+	return err
+}
+
+// str looks up a string in the string table.
+func (p *profile) str(idx int64) string {
+	if idx < 0 || idx >= int64(len(p.strings)) {
+		return ""
+	}
+
+	return p.strings[idx]
+}
@@ -0,0 +1,104 @@
+package prof
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// the wire types of the protocol buffer encoding
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+var errTruncated = errors.New("truncated protocol buffer")
+
+// field is a single decoded field of a protocol buffer message. Varints and
+// fixed size numbers are stored in num, length delimited fields in data.
+type field struct {
+	number int
+	wire   int
+	num    uint64
+	data   []byte
+}
+
+// fields iterates over the fields of a protocol buffer message, calling fn
+// for every field in the order they're encoded.
+func fields(msg []byte, fn func(f field) error) error {
+	for len(msg) > 0 {
+		key, n := binary.Uvarint(msg)
+		if n <= 0 {
+			return errTruncated
+		}
+		msg = msg[n:]
+
+		f := field{number: int(key >> 3), wire: int(key & 7)}
+		switch f.wire {
+		case wireVarint:
+			f.num, n = binary.Uvarint(msg)
+			if n <= 0 {
+				return errTruncated
+			}
+			msg = msg[n:]
+
+		case wireFixed64:
+			if len(msg) < 8 {
+				return errTruncated
+			}
+			f.num = binary.LittleEndian.Uint64(msg)
+			msg = msg[8:]
+
+		case wireFixed32:
+			if len(msg) < 4 {
+				return errTruncated
+			}
+			f.num = uint64(binary.LittleEndian.Uint32(msg))
+			msg = msg[4:]
+
+		case wireBytes:
+			size, n := binary.Uvarint(msg)
+			if n <= 0 || uint64(len(msg)-n) < size {
+				return errTruncated
+			}
+			f.data = msg[n : n+int(size)]
+			msg = msg[n+int(size):]
+
+		default:
+			return fmt.Errorf("unsupported wire type %d", f.wire)
+		}
+
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// uints decodes a repeated integer field, which is either a single varint or
+// a packed list of varints.
+func (f field) uints() ([]uint64, error) {
+	if f.wire != wireBytes {
+		return []uint64{f.num}, nil
+	}
+
+	var (
+		values []uint64
+		data   = f.data
+	)
+
+	for len(data) > 0 {
+		v, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, errTruncated
+		}
+
+		values = append(values, v)
+		data = data[n:]
+	}
+
+	return values, nil
+}
@@ -0,0 +1,189 @@
+package prof
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Summary lists the functions that contributed most to a profile, much like
+// `go tool pprof -top` does.
+type Summary struct {
+	SampleType string
+	Unit       string
+	Total      int64
+	Entries    []Entry
+}
+
+// Entry holds the totals of a single function. Flat counts the samples taken
+// in the function itself, Cum also counts those taken in the functions it
+// called.
+type Entry struct {
+	Function string
+	Flat     int64
+	Cum      int64
+}
+
+// Top reads a profile and summarizes it by the n functions with the highest
+// flat value for the given sample type, such as "cpu" or "alloc_space". An
+// empty sample type selects the last one, which is what pprof defaults to.
+//
+// Samples taken in the profiler itself are left out. Allocations of the CPU
+// profiler would otherwise make up a large part of a memory profile taken
+// during the same run.
+func Top(r io.Reader, sampleType string, n int) (Summary, error) {
+	p, err := parseProfile(r)
+	if err != nil {
+		return Summary{}, err
+	}
+
+	if len(p.sampleTypes) == 0 {
+		return Summary{}, fmt.Errorf("profile holds no sample types")
+	}
+
+	idx := len(p.sampleTypes) - 1
+	if sampleType != "" {
+		idx = slices.IndexFunc(p.sampleTypes, func(vt valueType) bool {
+			return p.str(vt.typ) == sampleType
+		})
+
+		if idx < 0 {
+			return Summary{}, fmt.Errorf("profile holds no %q samples", sampleType)
+		}
+	}
+
+	summary := Summary{
+		SampleType: p.str(p.sampleTypes[idx].typ),
+		Unit:       p.str(p.sampleTypes[idx].unit),
+	}
+
+	entries := map[string]*Entry{}
+	entry := func(function uint64) *Entry {
+		name := p.str(p.functions[function])
+		if _, found := entries[name]; !found {
+			entries[name] = &Entry{Function: name}
+		}
+
+		return entries[name]
+	}
+
+	for _, s := range p.samples {
+		if idx >= len(s.values) || s.values[idx] == 0 || p.profiler(s) {
+			continue
+		}
+
+		value := s.values[idx]
+		summary.Total += value
+
+		// the first location is the leaf, with the innermost inlined
+		// function first
+		if len(s.locations) > 0 {
+			if functions := p.locations[s.locations[0]]; len(functions) > 0 {
+				entry(functions[0]).Flat += value
+			}
+		}
+
+		// recursive functions only count once towards their cumulative value
+		seen := map[*Entry]bool{}
+		for _, loc := range s.locations {
+			for _, function := range p.locations[loc] {
+				e := entry(function)
+				if !seen[e] {
+					e.Cum += value
+					seen[e] = true
+				}
+			}
+		}
+	}
+
+	for _, e := range entries {
+		summary.Entries = append(summary.Entries, *e)
+	}
+
+	slices.SortFunc(summary.Entries, func(a, b Entry) int {
+		return cmp.Or(
+			cmp.Compare(b.Flat, a.Flat),
+			cmp.Compare(b.Cum, a.Cum),
+			cmp.Compare(a.Function, b.Function),
+		)
+	})
+
+	if len(summary.Entries) > n {
+		summary.Entries = summary.Entries[:n]
+	}
+
+	return summary, nil
+}
+
+// profiler reports whether the sample was taken in runtime/pprof.
+func (p *profile) profiler(s sample) bool {
+	for _, loc := range s.locations {
+		for _, function := range p.locations[loc] {
+			if strings.HasPrefix(p.str(p.functions[function]), "runtime/pprof.") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Write writes the summary as a table.
+func (s Summary) Write(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "%s total: %s\n", s.SampleType, s.format(s.Total)); err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "flat\tflat%\tcum\tcum%\t")
+	for _, e := range s.Entries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t  %s\n",
+			s.format(e.Flat), s.percentage(e.Flat),
+			s.format(e.Cum), s.percentage(e.Cum),
+			e.Function,
+		)
+	}
+
+	return tw.Flush()
+}
+
+func (s Summary) format(value int64) string {
+	switch s.Unit {
+	case "nanoseconds":
+		return time.Duration(value).String()
+
+	case "bytes":
+		return formatBytes(value)
+	}
+
+	return fmt.Sprint(value)
+}
+
+func (s Summary) percentage(value int64) string {
+	if s.Total == 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%.2f%%", float64(value)/float64(s.Total)*100)
+}
+
+func formatBytes(n int64) string {
+	units := []string{"B", "kB", "MB", "GB", "TB"}
+
+	value := float64(n)
+	unit := 0
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+
+	if unit == 0 {
+		return fmt.Sprintf("%d%s", n, units[unit])
+	}
+
+	return fmt.Sprintf("%.2f%s", value, units[unit])
+}
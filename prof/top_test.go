@@ -0,0 +1,202 @@
+package prof
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func assert(t *testing.T, a, b any, msg string) {
+	t.Helper()
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("assertion failed: %s. Expected %+v, got %+v", msg, b, a)
+	}
+}
+
+// message encodes a protocol buffer message for the tests, fields are given
+// as pairs of field numbers and values. Values are either integers, encoded
+// as varints, or strings and byte slices, encoded as length delimited.
+func message(pairs ...any) []byte {
+	var b []byte
+	for i := 0; i < len(pairs); i += 2 {
+		number := uint64(pairs[i].(int))
+		switch v := pairs[i+1].(type) {
+		case int:
+			b = binary.AppendUvarint(b, number<<3|wireVarint)
+			b = binary.AppendUvarint(b, uint64(v))
+
+		case string:
+			b = binary.AppendUvarint(b, number<<3|wireBytes)
+			b = binary.AppendUvarint(b, uint64(len(v)))
+			b = append(b, v...)
+
+		case []byte:
+			b = binary.AppendUvarint(b, number<<3|wireBytes)
+			b = binary.AppendUvarint(b, uint64(len(v)))
+			b = append(b, v...)
+		}
+	}
+
+	return b
+}
+
+func packed(values ...int) []byte {
+	var b []byte
+	for _, v := range values {
+		b = binary.AppendUvarint(b, uint64(v))
+	}
+
+	return b
+}
+
+// testProfile builds a profile where main calls solve, which calls parse. The
+// location of solve also covers the inlined helper.
+func testProfile() []byte {
+	return message(
+		1, message(1, 1, 2, 2), // samples/count
+		1, message(1, 3, 2, 4), // cpu/nanoseconds
+
+		// parse < solve < main
+		2, message(1, packed(1, 2, 3), 2, packed(1, 100)),
+		// helper inlined in solve < main, unpacked
+		2, message(1, 2, 1, 3, 2, 1, 2, 50),
+		// main
+		2, message(1, packed(3), 2, packed(1, 10)),
+
+		4, message(1, 1, 4, message(1, 1, 2, 12)),
+		4, message(1, 2, 4, message(1, 4, 2, 3), 4, message(1, 2, 2, 20)),
+		4, message(1, 3, 4, message(1, 3, 2, 30)),
+
+		5, message(1, 1, 2, 5),
+		5, message(1, 2, 2, 6),
+		5, message(1, 3, 2, 7),
+		5, message(1, 4, 2, 8),
+
+		6, "",
+		6, "samples",
+		6, "count",
+		6, "cpu",
+		6, "nanoseconds",
+		6, "main.parse",
+		6, "main.solve",
+		6, "main.main",
+		6, "main.helper",
+	)
+}
+
+func TestTop(t *testing.T) {
+	summary, err := Top(bytes.NewReader(testProfile()), "", 10)
+	assert(t, err, nil, "unexpected error")
+	assert(t, summary.SampleType, "cpu", "should default to the last sample type")
+	assert(t, summary.Unit, "nanoseconds", "incorrect unit")
+	assert(t, summary.Total, int64(160), "incorrect total")
+	assert(t, summary.Entries, []Entry{
+		{Function: "main.parse", Flat: 100, Cum: 100},
+		{Function: "main.helper", Flat: 50, Cum: 150},
+		{Function: "main.main", Flat: 10, Cum: 160},
+		{Function: "main.solve", Flat: 0, Cum: 150},
+	}, "incorrect entries")
+
+	summary, err = Top(bytes.NewReader(testProfile()), "samples", 2)
+	assert(t, err, nil, "unexpected error")
+	assert(t, summary.Total, int64(3), "incorrect total")
+	assert(t, len(summary.Entries), 2, "should only list the top entries")
+
+	_, err = Top(bytes.NewReader(testProfile()), "alloc_space", 2)
+	assert(t, err != nil, true, "expected error for missing sample type")
+
+	t.Run("gzip", func(t *testing.T) {
+		var b bytes.Buffer
+		gz := gzip.NewWriter(&b)
+		gz.Write(testProfile())
+		gz.Close()
+
+		summary, err := Top(&b, "cpu", 1)
+		assert(t, err, nil, "unexpected error")
+		assert(t, summary.Entries, []Entry{{Function: "main.parse", Flat: 100, Cum: 100}}, "incorrect entries")
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		p := testProfile()
+		_, err := Top(bytes.NewReader(p[:len(p)-3]), "cpu", 1)
+		assert(t, err != nil, true, "expected error for truncated profile")
+	})
+}
+
+func TestSummaryWrite(t *testing.T) {
+	summary, err := Top(bytes.NewReader(testProfile()), "cpu", 2)
+	assert(t, err, nil, "unexpected error")
+
+	var b strings.Builder
+	assert(t, summary.Write(&b), nil, "unexpected error")
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	assert(t, len(lines), 4, "incorrect number of lines")
+	assert(t, lines[0], "cpu total: 160ns", "incorrect total")
+	assert(t, strings.Fields(lines[2]), []string{"100ns", "62.50%", "100ns", "62.50%", "main.parse"}, "incorrect first entry")
+}
+
+var sink [][]byte
+
+//go:noinline
+func allocate() {
+	for range 1000 {
+		sink = append(sink, make([]byte, 1024))
+	}
+}
+
+//go:noinline
+func spin(d time.Duration) int {
+	n := 0
+	for start := time.Now(); time.Since(start) < d; {
+		n++
+	}
+
+	return n
+}
+
+// TestTop_Runtime summarizes profiles written by runtime/pprof rather than
+// the hand built ones above.
+func TestTop_Runtime(t *testing.T) {
+	defer func(rate int) { runtime.MemProfileRate = rate }(runtime.MemProfileRate)
+	runtime.MemProfileRate = 1
+
+	dir := t.TempDir()
+	opts := Options{
+		CPUProfile: filepath.Join(dir, "cpu.out"),
+		MemProfile: filepath.Join(dir, "mem.out"),
+	}
+
+	stop, err := Start(opts)
+	assert(t, err, nil, "unexpected error starting profiles")
+	spin(200 * time.Millisecond)
+	allocate()
+	sink = nil
+	assert(t, stop(), nil, "unexpected error stopping profiles")
+
+	cpu, err := Summarize(opts.CPUProfile, "cpu", 1000)
+	assert(t, err, nil, "unexpected error summarizing CPU profile")
+	assert(t, cpu.Unit, "nanoseconds", "incorrect CPU unit")
+	assert(t, cpu.Total > 0, true, "CPU profile should hold samples")
+	assert(t, hasEntry(cpu, ".spin"), true, "CPU profile should list the spinning function")
+
+	mem, err := Summarize(opts.MemProfile, "alloc_space", 1000)
+	assert(t, err, nil, "unexpected error summarizing memory profile")
+	assert(t, mem.Unit, "bytes", "incorrect memory unit")
+	assert(t, mem.Total >= 1000*1024, true, "memory profile should hold the allocations")
+	assert(t, hasEntry(mem, ".allocate"), true, "memory profile should list the allocating function")
+	assert(t, hasEntry(mem, "runtime/pprof."), false, "memory profile shouldn't list the profiler")
+}
+
+func hasEntry(s Summary, function string) bool {
+	return slices.ContainsFunc(s.Entries, func(e Entry) bool {
+		return strings.Contains(e.Function, function)
+	})
+}